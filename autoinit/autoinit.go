@@ -0,0 +1,14 @@
+// Package autoinit initializes the python interpreter with the default options when the program starts.
+//
+// Import the package for its side effect:
+//
+//	import _ "go.nhat.io/python/v3/autoinit"
+package autoinit
+
+import python3 "go.nhat.io/python/v3"
+
+func init() { //nolint: gochecknoinits
+	if err := python3.Initialize(); err != nil {
+		panic(err)
+	}
+}
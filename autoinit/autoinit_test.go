@@ -0,0 +1,57 @@
+package autoinit_test
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cpy3 "go.nhat.io/cpy/v3"
+
+	python3 "go.nhat.io/python/v3"
+	_ "go.nhat.io/python/v3/autoinit"
+)
+
+// TestMain is the entry point for the test suite.
+func TestMain(m *testing.M) {
+	// The tests run on the goroutines of the testing package, so the main goroutine, which initialized the interpreter,
+	// releases the GIL for them. The state is not restored because TestFinalize finalizes the interpreter.
+	_ = python3.ReleaseGIL()
+
+	os.Exit(m.Run())
+}
+
+func TestAutoinit(t *testing.T) {
+	err := python3.WithGIL(func() error {
+		require.True(t, python3.IsInitialized())
+		require.ErrorIs(t, python3.Initialize(), python3.ErrPythonInterpreterInitialized)
+
+		math := python3.MustImportModule("math")
+
+		pi := math.GetAttr("pi")
+		defer pi.DecRef()
+
+		assert.InDelta(t, 3.14159, python3.AsFloat64(pi), 0.00001)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+}
+
+func TestFinalize(t *testing.T) {
+	// Finalize needs the GIL, and it unpins the goroutine that Initialize pins to its thread. The GIL is not released with
+	// PyGILState_Release, because its thread state is destroyed by the finalization.
+	runtime.LockOSThread()
+	cpy3.PyGILState_Ensure()
+
+	python3.Finalize()
+	python3.Finalize()
+
+	assert.False(t, python3.IsInitialized())
+
+	require.NoError(t, python3.Initialize())
+
+	python3.Finalize()
+}
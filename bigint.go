@@ -0,0 +1,80 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+
+static PyObject *longFromBytes(const unsigned char *bytes, size_t n, int negative) {
+	PyObject *v = _PyLong_FromByteArray(bytes, n, 0, 0);
+	PyObject *neg;
+
+	if (v == NULL || !negative) {
+		return v;
+	}
+
+	neg = PyNumber_Negative(v);
+	Py_DECREF(v);
+
+	return neg;
+}
+
+static int longToBytes(PyObject *v, unsigned char *bytes, size_t n) {
+	PyObject *abs = PyNumber_Absolute(v);
+	int rc;
+
+	if (abs == NULL) {
+		return -1;
+	}
+
+	rc = _PyLong_AsByteArray((PyLongObject *)abs, bytes, n, 0, 0);
+	Py_DECREF(abs);
+
+	return rc;
+}
+*/
+import "C"
+
+import (
+	"math/big"
+	"reflect"
+	"unsafe"
+)
+
+var bigIntType = reflect.TypeFor[big.Int]()
+
+// NewBigInt creates a new Python int object from v without loss of precision.
+func NewBigInt(v *big.Int) *Object {
+	b := v.Bytes()
+
+	return fromC(C.longFromBytes((*C.uchar)(unsafe.SliceData(b)), C.size_t(len(b)), boolToCInt(v.Sign() < 0)))
+}
+
+// AsBigInt converts a Python int object to a *big.Int without loss of precision. It returns nil if o is not an int.
+func AsBigInt(o *Object) *big.Int {
+	v, err := asBigInt(o)
+	if err != nil {
+		return nil
+	}
+
+	return v
+}
+
+func asBigInt(o *Object) (*big.Int, error) {
+	if !IsInt(o) {
+		return nil, &UnmarshalTypeError{Value: TypeName(o), Type: bigIntType}
+	}
+
+	b := make([]byte, (C._PyLong_NumBits(toC(o))+7)/8)
+
+	if len(b) > 0 && C.longToBytes(toC(o), (*C.uchar)(unsafe.SliceData(b)), C.size_t(len(b))) != 0 {
+		return nil, LastError()
+	}
+
+	v := new(big.Int).SetBytes(b)
+
+	if C._PyLong_Sign(toC(o)) < 0 {
+		v.Neg(v)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,132 @@
+package python_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+
+	v, ok := new(big.Int).SetString(s, 10)
+	require.True(t, ok)
+
+	return v
+}
+
+func TestBigInt(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		value    string
+	}{
+		{scenario: "zero", value: "0"},
+		{scenario: "small", value: "42"},
+		{scenario: "negative", value: "-42"},
+		{scenario: "max uint64", value: "18446744073709551615"},
+		{scenario: "wide", value: "123456789012345678901234567890123456789012345678901234567890"},
+		{scenario: "wide negative", value: "-340282366920938463463374607431768211456"},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			v := bigInt(t, tc.value)
+
+			o := python3.NewBigInt(v)
+			defer o.DecRef()
+
+			assert.True(t, python3.IsInt(o))
+			assert.Equal(t, tc.value, o.String())
+			assert.Equal(t, v, python3.AsBigInt(o))
+		})
+	}
+}
+
+func TestAsBigInt_NotInt(t *testing.T) {
+	withGIL(t)
+
+	assert.Nil(t, python3.AsBigInt(python3.NewString("42")))
+}
+
+func TestMarshal_BigInt(t *testing.T) {
+	withGIL(t)
+
+	v := bigInt(t, "-123456789012345678901234567890")
+
+	o, err := python3.Marshal(v)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.Equal(t, "-123456789012345678901234567890", o.String())
+
+	o, err = python3.Marshal(*v)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.Equal(t, "-123456789012345678901234567890", o.String())
+}
+
+func TestUnmarshal_BigInt(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`{"id": 2 ** 100, "ids": [1, -2 ** 70]}`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	type record struct {
+		ID  big.Int    `python:"id"`
+		IDs []*big.Int `python:"ids"`
+	}
+
+	actual, err := python3.UnmarshalAs[record](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, bigInt(t, "1267650600228229401496703205376"), &actual.ID)
+	assert.Equal(t, []*big.Int{big.NewInt(1), bigInt(t, "-1180591620717411303424")}, actual.IDs)
+}
+
+func TestUnmarshal_BigIntToAny(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`[1, 2 ** 64]`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	actual, err := python3.UnmarshalAs[any](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{int64(1), bigInt(t, "18446744073709551616")}, actual)
+}
+
+func TestUnmarshal_BigIntError(t *testing.T) {
+	withGIL(t)
+
+	var v big.Int
+
+	err := python3.Unmarshal(python3.NewFloat64(1.5), &v)
+
+	assert.EqualError(t, err, "python3: cannot unmarshal float into Go value of type big.Int")
+}
+
+func TestUnmarshal_Overflow(t *testing.T) {
+	withGIL(t)
+
+	var v int8
+
+	err := python3.Unmarshal(python3.NewInt(-129), &v)
+
+	var overflowErr python3.OverflowError
+
+	require.ErrorAs(t, err, &overflowErr)
+	assert.Equal(t, "python int -129 does not fit in Go value of type int8", overflowErr.Message)
+}
@@ -9,6 +9,8 @@ import (
 )
 
 func TestBool(t *testing.T) {
+	withGIL(t)
+
 	boolT := python3.NewBool(true)
 	boolF := python3.NewBool(false)
 
@@ -0,0 +1,155 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include <stdlib.h>
+#include "Python.h"
+
+// getBuffer exports the memory of o to a view allocated in C, so that the exporter can keep pointers to it.
+static Py_buffer *getBuffer(PyObject *o, int flags) {
+	Py_buffer *view = malloc(sizeof(Py_buffer));
+
+	if (view == NULL) {
+		PyErr_NoMemory();
+
+		return NULL;
+	}
+
+	if (PyObject_GetBuffer(o, view, flags) != 0) {
+		free(view);
+
+		return NULL;
+	}
+
+	return view;
+}
+
+static void releaseBuffer(Py_buffer *view) {
+	PyBuffer_Release(view);
+	free(view);
+}
+*/
+import "C"
+
+import "unsafe"
+
+// Buffer is a view of the memory of a Python object that supports the buffer protocol, such as bytes, bytearray,
+// memoryview, array.array or a numpy array. The memory is exposed to Go without copying, and it stays valid until the
+// buffer is released.
+type Buffer struct {
+	view *C.Py_buffer
+}
+
+// GetBuffer is a wrapper around the C function PyObject_GetBuffer. It requests a strided view with the format of the
+// items, which must be released with Release.
+func GetBuffer(o PyObjector) (*Buffer, error) {
+	view := C.getBuffer(toC(o), C.PyBUF_RECORDS_RO)
+	if view == nil {
+		return nil, LastError()
+	}
+
+	return &Buffer{view: view}, nil
+}
+
+// Release releases the buffer. The memory must not be used afterward.
+func (b *Buffer) Release() {
+	if b == nil || b.view == nil {
+		return
+	}
+
+	C.releaseBuffer(b.view)
+
+	b.view = nil
+}
+
+// Bytes returns the memory of a contiguous buffer without copying, or nil if the buffer is not contiguous, see Item.
+// The slice must not be written if the buffer is read-only.
+func (b *Buffer) Bytes() []byte {
+	if !b.IsContiguous() {
+		return nil
+	}
+
+	return unsafe.Slice((*byte)(b.view.buf), b.Len())
+}
+
+// Item returns the memory of the item at the given indices without copying, or nil if the number of indices does not
+// match the number of dimensions or an index is out of range.
+func (b *Buffer) Item(indices ...int) []byte {
+	shape := b.Shape()
+	if len(indices) != len(shape) {
+		return nil
+	}
+
+	cIndices := make([]C.Py_ssize_t, len(indices))
+
+	for i, index := range indices {
+		if index < 0 || index >= shape[i] {
+			return nil
+		}
+
+		cIndices[i] = C.Py_ssize_t(index)
+	}
+
+	p := C.PyBuffer_GetPointer(b.view, unsafe.SliceData(cIndices))
+
+	return unsafe.Slice((*byte)(p), b.ItemSize())
+}
+
+// Len returns the size of the items in bytes, which is the product of the shape and the item size.
+func (b *Buffer) Len() int {
+	return int(b.view.len)
+}
+
+// ItemSize returns the size of an item in bytes.
+func (b *Buffer) ItemSize() int {
+	return int(b.view.itemsize)
+}
+
+// Format returns the struct module format of the items, such as "B" for unsigned bytes or "d" for doubles.
+func (b *Buffer) Format() string {
+	if b.view.format == nil {
+		return "B"
+	}
+
+	return C.GoString(b.view.format)
+}
+
+// NDim returns the number of dimensions of the memory.
+func (b *Buffer) NDim() int {
+	return int(b.view.ndim)
+}
+
+// Shape returns the number of items in each dimension.
+func (b *Buffer) Shape() []int {
+	return b.ints(b.view.shape)
+}
+
+// Strides returns the number of bytes to skip to get to the next item in each dimension.
+func (b *Buffer) Strides() []int {
+	return b.ints(b.view.strides)
+}
+
+// ReadOnly returns true if the memory must not be written.
+func (b *Buffer) ReadOnly() bool {
+	return b.view.readonly != 0
+}
+
+// IsContiguous returns true if the items are laid out without gaps, in C or Fortran order.
+func (b *Buffer) IsContiguous() bool {
+	return C.PyBuffer_IsContiguous(b.view, 'A') == 1
+}
+
+// ints converts an array of ndim C integers to a slice.
+func (b *Buffer) ints(values *C.Py_ssize_t) []int {
+	if values == nil {
+		return []int{}
+	}
+
+	result := make([]int, b.NDim())
+
+	for i, v := range unsafe.Slice(values, b.NDim()) {
+		result[i] = int(v)
+	}
+
+	return result
+}
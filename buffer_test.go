@@ -0,0 +1,163 @@
+package python_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestGetBuffer(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario         string
+		expr             string
+		expectedBytes    []byte
+		expectedFormat   string
+		expectedItemSize int
+		expectedShape    []int
+		expectedStrides  []int
+		expectedReadOnly bool
+	}{
+		{
+			scenario:         "bytes",
+			expr:             `b"abcdef"`,
+			expectedBytes:    []byte("abcdef"),
+			expectedFormat:   "B",
+			expectedItemSize: 1,
+			expectedShape:    []int{6},
+			expectedStrides:  []int{1},
+			expectedReadOnly: true,
+		},
+		{
+			scenario:         "bytearray",
+			expr:             `bytearray(b"abcdef")`,
+			expectedBytes:    []byte("abcdef"),
+			expectedFormat:   "B",
+			expectedItemSize: 1,
+			expectedShape:    []int{6},
+			expectedStrides:  []int{1},
+		},
+		{
+			scenario:         "memoryview with shape",
+			expr:             `memoryview(bytearray(b"abcdef")).cast("B", (2, 3))`,
+			expectedBytes:    []byte("abcdef"),
+			expectedFormat:   "B",
+			expectedItemSize: 1,
+			expectedShape:    []int{2, 3},
+			expectedStrides:  []int{3, 1},
+		},
+		{
+			scenario:         "array",
+			expr:             `__import__("array").array("h", [1, 2])`,
+			expectedBytes:    binary.NativeEndian.AppendUint16(binary.NativeEndian.AppendUint16(nil, 1), 2),
+			expectedFormat:   "h",
+			expectedItemSize: 2,
+			expectedShape:    []int{2},
+			expectedStrides:  []int{2},
+		},
+		{
+			scenario:         "not contiguous",
+			expr:             `memoryview(b"abcdef")[::2]`,
+			expectedFormat:   "B",
+			expectedItemSize: 1,
+			expectedShape:    []int{3},
+			expectedStrides:  []int{2},
+			expectedReadOnly: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Eval(tc.expr, nil, nil)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			buf, err := python3.GetBuffer(o)
+			require.NoError(t, err)
+
+			defer buf.Release()
+
+			assert.Equal(t, tc.expectedBytes, buf.Bytes())
+			assert.Equal(t, tc.expectedFormat, buf.Format())
+			assert.Equal(t, tc.expectedItemSize, buf.ItemSize())
+			assert.Equal(t, len(tc.expectedShape), buf.NDim())
+			assert.Equal(t, tc.expectedShape, buf.Shape())
+			assert.Equal(t, tc.expectedStrides, buf.Strides())
+			assert.Equal(t, tc.expectedReadOnly, buf.ReadOnly())
+			assert.Equal(t, tc.expectedBytes != nil, buf.IsContiguous())
+		})
+	}
+}
+
+func TestGetBuffer_ZeroCopy(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewByteArray([]byte("hello"))
+	defer o.DecRef()
+
+	buf, err := python3.GetBuffer(o)
+	require.NoError(t, err)
+
+	buf.Bytes()[0] = 'H'
+
+	buf.Release()
+
+	assert.Equal(t, []byte("Hello"), python3.AsBytes(o))
+}
+
+func TestGetBuffer_Item(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`memoryview(b"abcdef")[::2]`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	buf, err := python3.GetBuffer(o)
+	require.NoError(t, err)
+
+	defer buf.Release()
+
+	assert.Nil(t, buf.Bytes())
+	assert.Equal(t, []byte("a"), buf.Item(0))
+	assert.Equal(t, []byte("c"), buf.Item(1))
+	assert.Equal(t, []byte("e"), buf.Item(2))
+	assert.Nil(t, buf.Item(3))
+	assert.Nil(t, buf.Item(0, 0))
+}
+
+func TestGetBuffer_ItemShape(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`memoryview(b"abcdef").cast("B", (2, 3))`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	buf, err := python3.GetBuffer(o)
+	require.NoError(t, err)
+
+	defer buf.Release()
+
+	assert.Equal(t, []byte("b"), buf.Item(0, 1))
+	assert.Equal(t, []byte("f"), buf.Item(1, 2))
+	assert.Nil(t, buf.Item(2, 0))
+}
+
+func TestGetBuffer_NotSupported(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewString("hello")
+	defer o.DecRef()
+
+	buf, err := python3.GetBuffer(o)
+
+	assert.Nil(t, buf)
+	assert.EqualError(t, err, "a bytes-like object is required, not 'str'")
+}
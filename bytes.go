@@ -0,0 +1,64 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// IsBytes returns true if o is a Python bytes object.
+func IsBytes(o PyObjector) bool {
+	return o.PyObject().Type() == cpy3.Bytes
+}
+
+// IsByteArray returns true if o is a Python bytearray object.
+func IsByteArray(o PyObjector) bool {
+	return o.PyObject().Type() == cpy3.ByteArray
+}
+
+// NewBytes creates a new Python bytes object with a copy of b.
+func NewBytes(b []byte) *Object {
+	return fromC(C.PyBytes_FromStringAndSize(cBytes(b), C.Py_ssize_t(len(b))))
+}
+
+// NewByteArray creates a new Python bytearray object with a copy of b.
+func NewByteArray(b []byte) *Object {
+	return fromC(C.PyByteArray_FromStringAndSize(cBytes(b), C.Py_ssize_t(len(b))))
+}
+
+// AsBytes returns a copy of the content of a Python bytes or bytearray object, or nil if o is neither. Use GetBuffer to
+// read the content without copying.
+func AsBytes(o *Object) []byte {
+	var (
+		data *C.char
+		size C.Py_ssize_t
+	)
+
+	switch {
+	case IsBytes(o):
+		data, size = C.PyBytes_AsString(toC(o)), C.PyBytes_Size(toC(o))
+
+	case IsByteArray(o):
+		data, size = C.PyByteArray_AsString(toC(o)), C.PyByteArray_Size(toC(o))
+
+	default:
+		return nil
+	}
+
+	b := make([]byte, int(size))
+
+	copy(b, unsafe.Slice((*byte)(unsafe.Pointer(data)), int(size)))
+
+	return b
+}
+
+// cBytes returns a pointer to the content of b, which is only read by the C functions that copy it.
+func cBytes(b []byte) *C.char {
+	return (*C.char)(unsafe.Pointer(unsafe.SliceData(b)))
+}
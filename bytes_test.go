@@ -0,0 +1,57 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestNewBytes(t *testing.T) {
+	withGIL(t)
+
+	b := []byte("hello\x00world")
+
+	o := python3.NewBytes(b)
+	defer o.DecRef()
+
+	b[0] = 'H'
+
+	assert.True(t, python3.IsBytes(o))
+	assert.False(t, python3.IsByteArray(o))
+	assert.Equal(t, `b'hello\x00world'`, o.String())
+	assert.Equal(t, []byte("hello\x00world"), python3.AsBytes(o))
+}
+
+func TestNewBytes_Empty(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewBytes(nil)
+	defer o.DecRef()
+
+	assert.True(t, python3.IsBytes(o))
+	assert.Equal(t, []byte{}, python3.AsBytes(o))
+}
+
+func TestNewByteArray(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewByteArray([]byte("hello"))
+	defer o.DecRef()
+
+	assert.True(t, python3.IsByteArray(o))
+	assert.False(t, python3.IsBytes(o))
+	assert.Equal(t, `bytearray(b'hello')`, o.String())
+	assert.Equal(t, []byte("hello"), python3.AsBytes(o))
+}
+
+func TestAsBytes_NotBytes(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewString("hello")
+	defer o.DecRef()
+
+	assert.False(t, python3.IsBytes(o))
+	assert.Nil(t, python3.AsBytes(o))
+}
@@ -0,0 +1,23 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// fromC converts a C PyObject to an Object.
+func fromC(o *C.PyObject) *Object {
+	return NewObject((*cpy3.PyObject)(unsafe.Pointer(o)))
+}
+
+// toC converts an object to a C PyObject.
+func toC(o PyObjector) *C.PyObject {
+	return (*C.PyObject)(unsafe.Pointer(o.PyObject()))
+}
@@ -0,0 +1,304 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// goValueAttr is the attribute of the instances of the classes defined by DefineClass that keeps the Go value.
+const goValueAttr = "__go_value__"
+
+// ErrInvalidConstructor indicates that the constructor of a class is not a function that returns *T or (*T, error).
+var ErrInvalidConstructor = errors.New("invalid class constructor")
+
+// DefineClass defines a Python class backed by the Go type T, and returns a new reference to the class.
+//
+// The constructor ctor is a function that returns *T or (*T, error). Calling the class calls ctor with the arguments
+// converted like NewFunction does, and the instance keeps the returned value alive for as long as Python holds a
+// reference to it. The exported methods of *T are the methods of the class, with their names in snake case, and the
+// String() string method is __str__. Like with NewFunction, a method gives away the Python objects that it returns. The
+// fields of T are properties named like their keys in Marshal, which read and write the fields of the Go value.
+//
+// Once the class is defined, Marshal converts *T to an instance of the class that shares the Go value, and Unmarshal
+// converts an instance to the *T it keeps. The class is defined in the current interpreter.
+func DefineClass[T any](name string, ctor any) (*Object, error) { //nolint: cyclop,funlen
+	t := reflect.TypeFor[T]()
+	ptr := reflect.PointerTo(t)
+
+	init, err := classInit(ptr, ctor)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := NewDictObject()
+	defer namespace.DecRef()
+
+	slots := NewTupleObject(1)
+	defer slots.DecRef()
+
+	slots.Set(0, goValueAttr)
+	namespace.Set("__slots__", slots)
+
+	if err := setClassMethod(namespace, "__init__", init); err != nil {
+		return nil, err
+	}
+
+	if t.Kind() == reflect.Struct {
+		builtins, err := ImportModule("builtins")
+		if err != nil {
+			return nil, err
+		}
+
+		property := builtins.GetAttr("property")
+		defer property.DecRef()
+
+		for _, f := range cachedTypeFields(t) {
+			if err := setClassProperty[T](namespace, property, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range ptr.NumMethod() {
+		m := ptr.Method(i)
+		methodName := snakeCase(m.Name)
+
+		if m.Name == "String" && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.String {
+			methodName = "__str__"
+		}
+
+		if err := setClassMethod(namespace, methodName, m.Func); err != nil {
+			return nil, err
+		}
+	}
+
+	bases := NewTupleObject(0)
+	defer bases.DecRef()
+
+	class, err := NewObject(cpy3.Type).Call(name, bases, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	interp := currentInterpreter()
+	interp.classes.Store(t, class)
+
+	interp.registerFinalizer(func() {
+		interp.classes.CompareAndDelete(t, class)
+		class.DecRef()
+	})
+
+	class.PyObject().IncRef()
+
+	return class, nil
+}
+
+// MustDefineClass defines a Python class backed by the Go type T, and panics if it fails.
+func MustDefineClass[T any](name string, ctor any) *Object {
+	class, err := DefineClass[T](name, ctor)
+	if err != nil {
+		panic(err)
+	}
+
+	return class
+}
+
+// classInit returns the __init__ method of a class that calls ctor and keeps the result in the instance.
+func classInit(ptr reflect.Type, ctor any) (reflect.Value, error) {
+	rv := reflect.ValueOf(ctor)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return reflect.Value{}, fmt.Errorf("%w: %T", ErrInvalidConstructor, ctor)
+	}
+
+	ct := rv.Type()
+
+	switch {
+	case ct.NumOut() == 1 && ct.Out(0) == ptr:
+	case ct.NumOut() == 2 && ct.Out(0) == ptr && ct.Out(1) == errorType:
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: %s must return %s or (%s, error)", ErrInvalidConstructor, ct, ptr, ptr)
+	}
+
+	in := make([]reflect.Type, 0, ct.NumIn()+1)
+	in = append(in, objectType)
+
+	for i := range ct.NumIn() {
+		in = append(in, ct.In(i))
+	}
+
+	initType := reflect.FuncOf(in, []reflect.Type{errorType}, ct.IsVariadic())
+
+	return reflect.MakeFunc(initType, func(args []reflect.Value) []reflect.Value {
+		var out []reflect.Value
+
+		if ct.IsVariadic() {
+			out = rv.CallSlice(args[1:])
+		} else {
+			out = rv.Call(args[1:])
+		}
+
+		err := func() error {
+			if len(out) == 2 && !out[1].IsNil() {
+				return out[1].Interface().(error) //nolint: errcheck,forcetypeassert
+			}
+
+			if out[0].IsNil() {
+				return fmt.Errorf("%s returned a nil value", ct) //nolint: err113
+			}
+
+			return setGoValue(args[0].Interface().(*Object), out[0]) //nolint: errcheck,forcetypeassert
+		}()
+
+		result := reflect.New(errorType).Elem()
+
+		if err != nil {
+			result.Set(reflect.ValueOf(err))
+		}
+
+		return []reflect.Value{result}
+	}), nil
+}
+
+// setClassMethod adds a method that calls fn, whose first parameter is the instance, to the namespace of a class.
+func setClassMethod(namespace *DictObject, name string, fn reflect.Value) error {
+	f, err := newFunction(name, fn)
+	if err != nil {
+		return err
+	}
+
+	defer f.DecRef()
+
+	method := C.PyInstanceMethod_New(toC(f))
+	if method == nil {
+		return LastError()
+	}
+
+	defer fromC(method).DecRef()
+
+	return namespace.AsObject().TrySetItem(name, fromC(method))
+}
+
+// setClassProperty adds a property that reads and writes the field f of T to the namespace of a class.
+func setClassProperty[T any](namespace *DictObject, property *Object, f field) error {
+	// The field keeps its Python objects, so the getter returns new references.
+	fget, err := NewFunction(f.name, func(v *T) (*Object, error) {
+		fv, ok := fieldByIndex(reflect.ValueOf(v).Elem(), f.index)
+		if !ok {
+			return newNone(), nil
+		}
+
+		return marshalNewRef(fv.Interface())
+	})
+	if err != nil {
+		return err
+	}
+
+	defer fget.DecRef()
+
+	fset, err := NewFunction(f.name, func(v *T, value *Object) error {
+		fv, ok := fieldByIndexAlloc(reflect.ValueOf(v).Elem(), f.index)
+		if !ok {
+			return fmt.Errorf("cannot set field %s", f.name) //nolint: err113
+		}
+
+		return Unmarshal(value, fv.Addr().Interface())
+	})
+	if err != nil {
+		return err
+	}
+
+	defer fset.DecRef()
+
+	prop, err := property.Call(fget, fset)
+	if err != nil {
+		return err
+	}
+
+	defer prop.DecRef()
+
+	return namespace.AsObject().TrySetItem(f.name, prop)
+}
+
+// setGoValue makes an instance of a class defined by DefineClass keep the Go value v.
+func setGoValue(o *Object, v reflect.Value) error {
+	capsule := newHandleCapsule(v.Interface())
+	if capsule == nil {
+		return LastError()
+	}
+
+	defer capsule.DecRef()
+
+	return o.TrySetAttr(goValueAttr, capsule)
+}
+
+// goValueOf returns the Go value kept by an instance of a class defined by DefineClass.
+func goValueOf(o *Object) (any, bool) {
+	if !o.PyObject().HasAttrString(goValueAttr) {
+		return nil, false
+	}
+
+	capsule := o.GetAttr(goValueAttr)
+	defer capsule.DecRef()
+
+	return valueFromCapsule(capsule)
+}
+
+// marshalInstance converts v to an instance of the class defined for its type, if any.
+func marshalInstance(v reflect.Value) (*Object, bool, error) {
+	if v.Kind() != reflect.Pointer {
+		return nil, false, nil
+	}
+
+	c, ok := currentInterpreter().classes.Load(v.Type().Elem())
+	if !ok {
+		return nil, false, nil
+	}
+
+	class := c.(*Object) //nolint: errcheck,forcetypeassert
+
+	o, err := class.TryCallMethod("__new__", class)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if err := setGoValue(o, v); err != nil {
+		o.DecRef()
+
+		return nil, true, err
+	}
+
+	return o, true, nil
+}
+
+// snakeCase converts a Go name, such as FullName or UserID, to snake case, such as full_name or user_id.
+func snakeCase(s string) string {
+	runes := []rune(s)
+
+	var b strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
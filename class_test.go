@@ -0,0 +1,214 @@
+package python_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+type counter struct {
+	Name  string `python:"name"`
+	Count int    `python:"count"`
+}
+
+type counterOptions struct {
+	Start int `python:"start"`
+}
+
+func newCounter(name string, opts counterOptions) (*counter, error) {
+	if name == "" {
+		return nil, errors.New("missing name")
+	}
+
+	return &counter{Name: name, Count: opts.Start}, nil
+}
+
+func (c *counter) Increment(n int) int {
+	c.Count += n
+
+	return c.Count
+}
+
+func (c *counter) CloneAs(name string) *counter {
+	return &counter{Name: name, Count: c.Count}
+}
+
+func (c *counter) String() string {
+	return fmt.Sprintf("%s=%d", c.Name, c.Count)
+}
+
+func defineCounter(t *testing.T) *python3.DictObject {
+	t.Helper()
+
+	class, err := python3.DefineClass[counter]("Counter", newCounter)
+	require.NoError(t, err)
+
+	t.Cleanup(class.DecRef)
+
+	globals := python3.NewDictObject()
+	t.Cleanup(globals.DecRef)
+
+	globals.Set("Counter", class)
+
+	return globals
+}
+
+func TestDefineClass(t *testing.T) {
+	withGIL(t)
+
+	globals := defineCounter(t)
+
+	code := "c = Counter('clicks', start=2)\n" +
+		"c.increment(3)\n" +
+		"c.count += 1\n" +
+		"result = (c.name, c.count, str(c), isinstance(c, Counter))\n"
+
+	err := python3.Exec(code, globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "('clicks', 6, 'clicks=6', True)", globals.Get("result").String())
+
+	c, err := python3.UnmarshalAs[*counter](globals.Get("c"))
+	require.NoError(t, err)
+
+	assert.Equal(t, &counter{Name: "clicks", Count: 6}, c)
+
+	// The Go value is shared with Python.
+	c.Increment(10)
+
+	err = python3.Exec("result = c.count", globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 16, python3.AsInt(globals.Get("result")))
+}
+
+func TestDefineClass_MarshalInstance(t *testing.T) {
+	withGIL(t)
+
+	globals := defineCounter(t)
+
+	c := &counter{Name: "views", Count: 1}
+
+	o, err := python3.Marshal(c)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	globals.Set("c", o)
+
+	err = python3.Exec("clone = c.clone_as('copy')\nc.increment(1)\nresult = (type(clone) is Counter, str(clone), str(c))\n", globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "(True, 'copy=1', 'views=2')", globals.Get("result").String())
+	assert.Equal(t, 2, c.Count)
+}
+
+type labeled struct {
+	Labels *python3.Object `python:"labels"`
+}
+
+func TestDefineClass_ObjectProperty(t *testing.T) {
+	withGIL(t)
+
+	labels := python3.MustMarshal([]string{"a", "b"})
+	defer labels.DecRef()
+
+	class, err := python3.DefineClass[labeled]("Labeled", func() *labeled {
+		labels.PyObject().IncRef()
+
+		return &labeled{Labels: labels}
+	})
+	require.NoError(t, err)
+
+	defer class.DecRef()
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("Labeled", class)
+
+	err = python3.Exec("item = Labeled()\nfor _ in range(3):\n    result = item.labels\n", globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "['a', 'b']", globals.Get("result").String())
+
+	// The labels are referenced by the test, the Go value, and the result.
+	assert.Equal(t, 3, refCount(t, labels))
+}
+
+func TestDefineClass_Errors(t *testing.T) {
+	withGIL(t)
+
+	globals := defineCounter(t)
+
+	testCases := []struct {
+		scenario string
+		code     string
+		expected string
+	}{
+		{
+			scenario: "constructor error",
+			code:     "Counter('')",
+			expected: "missing name",
+		},
+		{
+			scenario: "wrong arguments",
+			code:     "Counter()",
+			expected: "__init__() takes 2 positional arguments but 1 were given",
+		},
+		{
+			scenario: "wrong property type",
+			code:     "Counter('clicks').count = 'many'",
+			expected: "python3: cannot unmarshal str into Go value of type int",
+		},
+		{
+			scenario: "unknown attribute",
+			code:     "Counter('clicks').unknown = 1",
+			expected: "'Counter' object has no attribute 'unknown'",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			err := python3.Exec(tc.code, globals, nil)
+
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestDefineClass_InvalidConstructor(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		ctor     any
+		expected string
+	}{
+		{
+			scenario: "not a function",
+			ctor:     42,
+			expected: "invalid class constructor: int",
+		},
+		{
+			scenario: "wrong result",
+			ctor:     func() counter { return counter{} },
+			expected: "invalid class constructor: func() python_test.counter must return *python_test.counter or (*python_test.counter, error)",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			class, err := python3.DefineClass[counter]("Counter", tc.ctor)
+
+			assert.Nil(t, class)
+			require.ErrorIs(t, err, python3.ErrInvalidConstructor)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
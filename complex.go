@@ -0,0 +1,27 @@
+package python
+
+import cpy3 "go.nhat.io/cpy/v3"
+
+// IsComplex returns true if the object is a Python complex.
+func IsComplex(o PyObjector) bool {
+	return o.PyObject().Type() == cpy3.Complex
+}
+
+// NewComplex creates a new Python complex object.
+func NewComplex(v complex128) *Object {
+	return NewObject(newPyComplex(v))
+}
+
+// newPyComplex creates a new cpy3 Python complex object.
+func newPyComplex(v complex128) *cpy3.PyObject {
+	return cpy3.PyComplex_FromDoubles(real(v), imag(v))
+}
+
+// AsComplex128 converts a Python object to a complex128.
+func AsComplex128(o *Object) complex128 {
+	return asComplex128(o.PyObject())
+}
+
+func asComplex128(o *cpy3.PyObject) complex128 {
+	return complex(cpy3.PyComplex_RealAsDouble(o), cpy3.PyComplex_ImagAsDouble(o))
+}
@@ -0,0 +1,24 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestComplex(t *testing.T) {
+	withGIL(t)
+
+	c := python3.NewComplex(complex(1.5, -2))
+
+	assert.NotNil(t, c)
+	assert.True(t, python3.IsComplex(c))
+	assert.Equal(t, complex(1.5, -2), python3.AsComplex128(c))
+	assert.Equal(t, "(1.5-2j)", c.String())
+
+	assert.False(t, python3.IsComplex(python3.NewFloat64(1.5)))
+	assert.False(t, python3.IsComplex(python3.NewInt(42)))
+	assert.False(t, python3.IsComplex(python3.NewString("1+2j")))
+}
@@ -0,0 +1,378 @@
+package python
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	durationType  = reflect.TypeFor[time.Duration]()
+	dateType      = reflect.TypeFor[Date]()
+	timeOfDayType = reflect.TypeFor[TimeOfDay]()
+)
+
+// Date is a date without a time of day or a location, like a Python datetime.date.
+type Date struct {
+	Year  int        // Year, such as 2006.
+	Month time.Month // Month of the year, January = 1.
+	Day   int        // Day of the month, starting at 1.
+}
+
+// DateOf returns the date of t in the location of t.
+func DateOf(t time.Time) Date {
+	var d Date
+
+	d.Year, d.Month, d.Day = t.Date()
+
+	return d
+}
+
+// In returns the time of the start of the date in the location loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns the date in the format YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// TimeOfDay is a time of day without a date or a location, like a Python datetime.time.
+type TimeOfDay struct {
+	Hour       int // Hour of the day, in the range [0, 23].
+	Minute     int // Minute of the hour, in the range [0, 59].
+	Second     int // Second of the minute, in the range [0, 59].
+	Nanosecond int // Nanosecond of the second, in the range [0, 999999999].
+}
+
+// TimeOfDayOf returns the time of day of t in the location of t.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// String returns the time of day in the format HH:MM:SS, followed by the fraction of the second if it is not zero.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+
+	if t.Nanosecond == 0 {
+		return s
+	}
+
+	return s + fmt.Sprintf(".%09d", t.Nanosecond)
+}
+
+// IsDateTime returns true if o is a Python datetime.datetime object.
+func IsDateTime(o PyObjector) bool {
+	return isInstanceOf(o, "datetime", "datetime")
+}
+
+// IsDate returns true if o is a Python datetime.date object that is not a datetime.datetime object.
+func IsDate(o PyObjector) bool {
+	return isInstanceOf(o, "datetime", "date") && !IsDateTime(o)
+}
+
+// IsTime returns true if o is a Python datetime.time object.
+func IsTime(o PyObjector) bool {
+	return isInstanceOf(o, "datetime", "time")
+}
+
+// IsTimeDelta returns true if o is a Python datetime.timedelta object.
+func IsTimeDelta(o PyObjector) bool {
+	return isInstanceOf(o, "datetime", "timedelta")
+}
+
+// NewDateTime creates a new aware Python datetime.datetime object for t. The time zone is datetime.timezone.utc if the
+// location of t is time.UTC, otherwise a fixed offset named after the zone abbreviation of t. Python datetimes have a
+// microsecond precision, so the nanoseconds are truncated.
+func NewDateTime(t time.Time) (*Object, error) {
+	tz, err := newTimeZone(t)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tz.DecRef()
+
+	args := []any{
+		t.Year(), int(t.Month()), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond() / int(time.Microsecond),
+	}
+
+	return callDateTime("datetime", args, map[string]any{"tzinfo": tz})
+}
+
+// newTimeZone returns the Python time zone of the location of t at the time t.
+func newTimeZone(t time.Time) (*Object, error) {
+	datetime, err := ImportModule("datetime")
+	if err != nil {
+		return nil, err
+	}
+
+	timezone, err := datetime.TryGetAttr("timezone")
+	if err != nil {
+		return nil, err
+	}
+
+	defer timezone.DecRef()
+
+	if t.Location() == time.UTC {
+		return timezone.TryGetAttr("utc")
+	}
+
+	name, offset := t.Zone()
+
+	delta, err := NewTimeDelta(time.Duration(offset) * time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	defer delta.DecRef()
+
+	return timezone.Call(delta, name)
+}
+
+// NewDate creates a new Python datetime.date object.
+func NewDate(d Date) (*Object, error) {
+	return callDateTime("date", []any{d.Year, int(d.Month), d.Day}, nil)
+}
+
+// NewTimeOfDay creates a new naive Python datetime.time object. The nanoseconds are truncated to microseconds.
+func NewTimeOfDay(t TimeOfDay) (*Object, error) {
+	return callDateTime("time", []any{t.Hour, t.Minute, t.Second, t.Nanosecond / int(time.Microsecond)}, nil)
+}
+
+// NewTimeDelta creates a new Python datetime.timedelta object. The nanoseconds are truncated to microseconds.
+func NewTimeDelta(d time.Duration) (*Object, error) {
+	us := int64(d / time.Microsecond)
+
+	return callDateTime("timedelta", nil, map[string]any{"seconds": us / 1e6, "microseconds": us % 1e6})
+}
+
+// callDateTime calls a class of the datetime module of the current interpreter. The module is looked up per
+// interpreter, because the sub-interpreters may use the pure Python implementation instead of the C one.
+func callDateTime(class string, args []any, kwargs map[string]any) (*Object, error) {
+	datetime, err := ImportModule("datetime")
+	if err != nil {
+		return nil, err
+	}
+
+	return datetime.CallMethodKw(class, args, kwargs)
+}
+
+// intAttrs returns the int attributes of o.
+func intAttrs(o *Object, names ...string) ([]int, error) {
+	values := make([]int, len(names))
+
+	for i, name := range names {
+		attr, err := o.TryGetAttr(name)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = AsInt(attr)
+
+		attr.DecRef()
+	}
+
+	return values, nil
+}
+
+// unmarshalDateTime converts a Python datetime to a time.Time. An aware datetime keeps its offset, in the location of
+// its time zone if it is a zoneinfo.ZoneInfo known to Go, otherwise in a fixed zone. A naive datetime is in loc.
+func unmarshalDateTime(o *Object, loc *time.Location) (time.Time, error) {
+	if !IsDateTime(o) {
+		return time.Time{}, &UnmarshalTypeError{Value: TypeName(o), Type: timeType}
+	}
+
+	f, err := intAttrs(o, "year", "month", "day", "hour", "minute", "second", "microsecond")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	wall := func(loc *time.Location) time.Time {
+		return time.Date(f[0], time.Month(f[1]), f[2], f[3], f[4], f[5], f[6]*int(time.Microsecond), loc)
+	}
+
+	tz, err := o.TryGetAttr("tzinfo")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer tz.DecRef()
+
+	if IsNone(tz) {
+		return wall(loc), nil
+	}
+
+	offset, err := o.TryCallMethod("utcoffset")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer offset.DecRef()
+
+	if IsNone(offset) {
+		return wall(loc), nil
+	}
+
+	d, err := unmarshalTimeDelta(offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	zone, err := zoneLocation(o, tz, d)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return wall(time.UTC).Add(-d).In(zone), nil
+}
+
+// zoneLocation returns the location of the time zone tz of the datetime o, whose UTC offset is offset.
+func zoneLocation(o, tz *Object, offset time.Duration) (*time.Location, error) {
+	if isUTC(tz) {
+		return time.UTC, nil
+	}
+
+	if key, err := tz.TryGetAttr("key"); err == nil {
+		defer key.DecRef()
+
+		if IsString(key) {
+			if loc, err := time.LoadLocation(key.String()); err == nil {
+				return loc, nil
+			}
+		}
+	}
+
+	name, err := o.TryCallMethod("tzname")
+	if err != nil {
+		return nil, err
+	}
+
+	defer name.DecRef()
+
+	var zone string
+
+	if IsString(name) {
+		zone = name.String()
+	}
+
+	return time.FixedZone(zone, int(offset/time.Second)), nil
+}
+
+// isUTC returns true if tz is datetime.timezone.utc.
+func isUTC(tz *Object) bool {
+	datetime, err := ImportModule("datetime")
+	if err != nil {
+		return false
+	}
+
+	timezone, err := datetime.TryGetAttr("timezone")
+	if err != nil {
+		return false
+	}
+
+	defer timezone.DecRef()
+
+	utc, err := timezone.TryGetAttr("utc")
+	if err != nil {
+		return false
+	}
+
+	defer utc.DecRef()
+
+	return tz.PyObject() == utc.PyObject()
+}
+
+func unmarshalDate(o *Object) (Date, error) {
+	if !IsDate(o) {
+		return Date{}, &UnmarshalTypeError{Value: TypeName(o), Type: dateType}
+	}
+
+	f, err := intAttrs(o, "year", "month", "day")
+	if err != nil {
+		return Date{}, err
+	}
+
+	return Date{Year: f[0], Month: time.Month(f[1]), Day: f[2]}, nil
+}
+
+// unmarshalTimeOfDay converts a Python time to a TimeOfDay. The time zone of an aware time is ignored.
+func unmarshalTimeOfDay(o *Object) (TimeOfDay, error) {
+	if !IsTime(o) {
+		return TimeOfDay{}, &UnmarshalTypeError{Value: TypeName(o), Type: timeOfDayType}
+	}
+
+	f, err := intAttrs(o, "hour", "minute", "second", "microsecond")
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+
+	return TimeOfDay{Hour: f[0], Minute: f[1], Second: f[2], Nanosecond: f[3] * int(time.Microsecond)}, nil
+}
+
+// unmarshalTimeDelta converts a Python timedelta to a time.Duration, and returns an OverflowError if it does not fit.
+func unmarshalTimeDelta(o *Object) (time.Duration, error) {
+	if !IsTimeDelta(o) {
+		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: durationType}
+	}
+
+	f, err := intAttrs(o, "days", "seconds", "microseconds")
+	if err != nil {
+		return 0, err
+	}
+
+	// The days alone may overflow an int64 of nanoseconds, so the seconds are checked first. The microseconds are
+	// always positive.
+	total := int64(f[0])*86400 + int64(f[1])
+	fraction := time.Duration(f[2]) * time.Microsecond
+
+	if total > math.MaxInt64/int64(time.Second) || total < math.MinInt64/int64(time.Second) ||
+		time.Duration(total)*time.Second > math.MaxInt64-fraction {
+		return 0, newOverflowError(o, durationType)
+	}
+
+	return time.Duration(total)*time.Second + fraction, nil
+}
+
+// unmarshalTime converts a Python datetime, date, time or timedelta to the matching Go type. It returns false if dest
+// is not one of these types, or if dest is a time.Duration and o is not a timedelta. The interfaces are handled by
+// unmarshalOther.
+func (d *decoder) unmarshalTime(o *Object, dest reflect.Value) (bool, error) {
+	var (
+		v   any
+		err error
+	)
+
+	switch dest.Type() {
+	case timeType:
+		v, err = unmarshalDateTime(o, d.naiveLocation)
+
+	case dateType:
+		v, err = unmarshalDate(o)
+
+	case timeOfDayType:
+		v, err = unmarshalTimeOfDay(o)
+
+	case durationType:
+		// Other Python objects are unmarshaled by the kind of time.Duration, which is an int64 of nanoseconds.
+		if !IsTimeDelta(o) {
+			return false, nil
+		}
+
+		v, err = unmarshalTimeDelta(o)
+
+	default:
+		return false, nil
+	}
+
+	if err != nil {
+		return true, err
+	}
+
+	dest.Set(reflect.ValueOf(v))
+
+	return true, nil
+}
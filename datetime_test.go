@@ -0,0 +1,490 @@
+package python_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func evalDateTime(t *testing.T, expr string) *python3.Object {
+	t.Helper()
+
+	o, err := python3.Eval(`__import__("datetime").`+expr, nil, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(o.DecRef)
+
+	return o
+}
+
+func TestDateTime(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		value          time.Time
+		expectedString string
+		expectedZone   string
+	}{
+		{
+			scenario:       "utc",
+			value:          time.Date(2024, time.March, 5, 6, 7, 8, 123456789, time.UTC),
+			expectedString: "2024-03-05 06:07:08.123456+00:00",
+			expectedZone:   "UTC",
+		},
+		{
+			scenario:       "fixed zone",
+			value:          time.Date(2024, time.March, 5, 6, 7, 8, 0, time.FixedZone("ICT", 7*60*60)),
+			expectedString: "2024-03-05 06:07:08+07:00",
+			expectedZone:   "ICT",
+		},
+		{
+			scenario:       "negative offset",
+			value:          time.Date(1999, time.December, 31, 23, 59, 59, 0, time.FixedZone("", -(3*60+30)*60)),
+			expectedString: "1999-12-31 23:59:59-03:30",
+			expectedZone:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.NewDateTime(tc.value)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			assert.True(t, python3.IsDateTime(o))
+			assert.False(t, python3.IsDate(o))
+			assert.Equal(t, tc.expectedString, o.String())
+
+			actual, err := python3.UnmarshalAs[time.Time](o)
+			require.NoError(t, err)
+
+			name, offset := actual.Zone()
+			_, expectedOffset := tc.value.Zone()
+
+			assert.Equal(t, tc.value.Truncate(time.Microsecond).UnixNano(), actual.UnixNano())
+			assert.Equal(t, tc.expectedZone, name)
+			assert.Equal(t, expectedOffset, offset)
+		})
+	}
+}
+
+func TestNewDateTime_OutOfRange(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.NewDateTime(time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, o)
+	require.EqualError(t, err, "year 10000 is out of range")
+}
+
+func TestUnmarshal_DateTime(t *testing.T) {
+	withGIL(t)
+
+	hcm := time.FixedZone("ICT", 7*60*60)
+
+	testCases := []struct {
+		scenario     string
+		expr         string
+		opts         []python3.UnmarshalOption
+		expected     time.Time
+		expectedZone string
+	}{
+		{
+			scenario:     "naive",
+			expr:         `datetime(2024, 3, 5, 6, 7, 8, 9)`,
+			expected:     time.Date(2024, time.March, 5, 6, 7, 8, 9000, time.UTC),
+			expectedZone: "UTC",
+		},
+		{
+			scenario:     "naive with location",
+			expr:         `datetime(2024, 3, 5, 6, 7, 8)`,
+			opts:         []python3.UnmarshalOption{python3.WithNaiveLocation(hcm)},
+			expected:     time.Date(2024, time.March, 5, 6, 7, 8, 0, hcm),
+			expectedZone: "ICT",
+		},
+		{
+			scenario:     "utc",
+			expr:         `datetime(2024, 3, 5, 6, 7, 8, tzinfo=__import__("datetime").timezone.utc)`,
+			opts:         []python3.UnmarshalOption{python3.WithNaiveLocation(hcm)},
+			expected:     time.Date(2024, time.March, 5, 6, 7, 8, 0, time.UTC),
+			expectedZone: "UTC",
+		},
+		{
+			scenario:     "fixed offset",
+			expr:         `datetime(2024, 3, 5, 6, 7, 8, tzinfo=__import__("datetime").timezone(__import__("datetime").timedelta(hours=-5)))`,
+			expected:     time.Date(2024, time.March, 5, 11, 7, 8, 0, time.UTC),
+			expectedZone: "UTC-05:00",
+		},
+		{
+			scenario:     "zoneinfo",
+			expr:         `datetime(2024, 7, 1, 12, tzinfo=__import__("zoneinfo").ZoneInfo("Europe/Paris"))`,
+			expected:     time.Date(2024, time.July, 1, 10, 0, 0, 0, time.UTC),
+			expectedZone: "CEST",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o := evalDateTime(t, tc.expr)
+
+			actual, err := python3.UnmarshalAs[time.Time](o, tc.opts...)
+			require.NoError(t, err)
+
+			name, _ := actual.Zone()
+
+			assert.True(t, tc.expected.Equal(actual), "expected %s, got %s", tc.expected, actual)
+			assert.Equal(t, tc.expectedZone, name)
+		})
+	}
+}
+
+func TestUnmarshal_DateTime_ZoneInfo(t *testing.T) {
+	withGIL(t)
+
+	o := evalDateTime(t, `datetime(2024, 1, 1, tzinfo=__import__("zoneinfo").ZoneInfo("America/New_York"))`)
+
+	actual, err := python3.UnmarshalAs[time.Time](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, "America/New_York", actual.Location().String())
+}
+
+func TestUnmarshal_DateTime_Interpreter(t *testing.T) {
+	withGIL(t)
+
+	interp := newInterpreter(t)
+
+	err := interp.Run(func() error {
+		expr := `__import__("datetime").datetime(2024, 3, 5, 6, 7, 8, tzinfo=__import__("datetime").timezone.utc)`
+
+		o, err := python3.Eval(expr, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		defer o.DecRef()
+
+		assert.True(t, python3.IsDateTime(o))
+
+		actual, err := python3.UnmarshalAs[time.Time](o)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, time.Date(2024, 3, 5, 6, 7, 8, 0, time.UTC), actual)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestTimeDelta(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		value          time.Duration
+		expectedString string
+	}{
+		{
+			scenario:       "zero",
+			value:          0,
+			expectedString: "0:00:00",
+		},
+		{
+			scenario:       "microseconds",
+			value:          90*time.Minute + 1500*time.Nanosecond,
+			expectedString: "1:30:00.000001",
+		},
+		{
+			scenario:       "negative",
+			value:          -time.Second,
+			expectedString: "-1 day, 23:59:59",
+		},
+		{
+			scenario:       "days",
+			value:          50*time.Hour + time.Second,
+			expectedString: "2 days, 2:00:01",
+		},
+		{
+			scenario:       "max",
+			value:          time.Duration(1<<63 - 1),
+			expectedString: "106751 days, 23:47:16.854775",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.NewTimeDelta(tc.value)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			assert.True(t, python3.IsTimeDelta(o))
+			assert.Equal(t, tc.expectedString, o.String())
+
+			actual, err := python3.UnmarshalAs[time.Duration](o)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.value.Truncate(time.Microsecond), actual)
+		})
+	}
+}
+
+func TestUnmarshal_TimeDelta_Overflow(t *testing.T) {
+	withGIL(t)
+
+	o := evalDateTime(t, `timedelta(days=200000)`)
+
+	actual, err := python3.UnmarshalAs[time.Duration](o)
+
+	assert.Zero(t, actual)
+	require.EqualError(t, err, "python timedelta 200000 days, 0:00:00 does not fit in Go value of type time.Duration")
+	assert.ErrorAs(t, err, new(python3.OverflowError))
+}
+
+func TestUnmarshal_Duration_FromInt(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewInt64(int64(time.Second))
+	defer o.DecRef()
+
+	actual, err := python3.UnmarshalAs[time.Duration](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Second, actual)
+}
+
+func TestDate(t *testing.T) {
+	withGIL(t)
+
+	d := python3.DateOf(time.Date(2024, time.February, 29, 23, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, python3.Date{Year: 2024, Month: time.February, Day: 29}, d)
+	assert.Equal(t, "2024-02-29", d.String())
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), d.In(time.UTC))
+
+	o, err := python3.NewDate(d)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.True(t, python3.IsDate(o))
+	assert.False(t, python3.IsDateTime(o))
+	assert.Equal(t, "2024-02-29", o.String())
+
+	actual, err := python3.UnmarshalAs[python3.Date](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, d, actual)
+}
+
+func TestNewDate_Invalid(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.NewDate(python3.Date{Year: 2023, Month: time.February, Day: 29})
+
+	assert.Nil(t, o)
+	require.EqualError(t, err, "day is out of range for month")
+}
+
+func TestTimeOfDay(t *testing.T) {
+	withGIL(t)
+
+	tod := python3.TimeOfDayOf(time.Date(2024, time.March, 5, 6, 7, 8, 123456789, time.UTC))
+
+	assert.Equal(t, python3.TimeOfDay{Hour: 6, Minute: 7, Second: 8, Nanosecond: 123456789}, tod)
+	assert.Equal(t, "06:07:08.123456789", tod.String())
+	assert.Equal(t, "06:07:08", python3.TimeOfDay{Hour: 6, Minute: 7, Second: 8}.String())
+
+	o, err := python3.NewTimeOfDay(tod)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.True(t, python3.IsTime(o))
+	assert.Equal(t, "06:07:08.123456", o.String())
+
+	actual, err := python3.UnmarshalAs[python3.TimeOfDay](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, python3.TimeOfDay{Hour: 6, Minute: 7, Second: 8, Nanosecond: 123456000}, actual)
+}
+
+func TestNewTimeOfDay_Invalid(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.NewTimeOfDay(python3.TimeOfDay{Hour: 24})
+
+	assert.Nil(t, o)
+	require.EqualError(t, err, "hour must be in 0..23")
+}
+
+func TestMarshal_Time(t *testing.T) {
+	withGIL(t)
+
+	type schedule struct {
+		At       time.Time         `python:"at"`
+		Every    time.Duration     `python:"every"`
+		Day      python3.Date      `python:"day"`
+		Opening  python3.TimeOfDay `python:"opening"`
+		Deadline *time.Time        `python:"deadline"`
+		Extra    map[string]any    `python:"extra"`
+	}
+
+	at := time.Date(2024, time.March, 5, 6, 7, 8, 0, time.UTC)
+	deadline := at.Add(time.Hour)
+
+	o, err := python3.Marshal(schedule{
+		At:       at,
+		Every:    15 * time.Minute,
+		Day:      python3.Date{Year: 2024, Month: time.March, Day: 5},
+		Opening:  python3.TimeOfDay{Hour: 9},
+		Deadline: &deadline,
+		Extra:    map[string]any{"since": python3.Date{Year: 2020, Month: time.January, Day: 1}},
+	})
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	expected := `{'at': datetime.datetime(2024, 3, 5, 6, 7, 8, tzinfo=datetime.timezone.utc), ` +
+		`'every': datetime.timedelta(seconds=900), ` +
+		`'day': datetime.date(2024, 3, 5), ` +
+		`'opening': datetime.time(9, 0), ` +
+		`'deadline': datetime.datetime(2024, 3, 5, 7, 7, 8, tzinfo=datetime.timezone.utc), ` +
+		`'extra': {'since': datetime.date(2020, 1, 1)}}`
+
+	repr, err := python3.Eval(`repr`, nil, nil)
+	require.NoError(t, err)
+
+	defer repr.DecRef()
+
+	actual, err := repr.Call(o)
+	require.NoError(t, err)
+
+	defer actual.DecRef()
+
+	assert.Equal(t, expected, actual.String())
+
+	var result schedule
+
+	require.NoError(t, python3.Unmarshal(o, &result))
+
+	assert.True(t, at.Equal(result.At))
+	assert.Equal(t, 15*time.Minute, result.Every)
+	assert.Equal(t, python3.Date{Year: 2024, Month: time.March, Day: 5}, result.Day)
+	assert.Equal(t, python3.TimeOfDay{Hour: 9}, result.Opening)
+	assert.True(t, deadline.Equal(*result.Deadline))
+	assert.Equal(t, map[string]any{"since": python3.Date{Year: 2020, Month: time.January, Day: 1}}, result.Extra)
+}
+
+func TestMarshal_TimePointers(t *testing.T) {
+	withGIL(t)
+
+	every := 90 * time.Second
+	day := python3.Date{Year: 2024, Month: time.March, Day: 5}
+	opening := python3.TimeOfDay{Hour: 9, Minute: 30}
+
+	testCases := []struct {
+		scenario string
+		value    any
+		expected string
+	}{
+		{scenario: "duration", value: &every, expected: "0:01:30"},
+		{scenario: "date", value: &day, expected: "2024-03-05"},
+		{scenario: "time of day", value: &opening, expected: "09:30:00"},
+		{
+			scenario: "struct fields",
+			value: struct {
+				Day   *python3.Date  `python:"day"`
+				Every *time.Duration `python:"every"`
+			}{Day: &day, Every: &every},
+			expected: "{'day': datetime.date(2024, 3, 5), 'every': datetime.timedelta(seconds=90)}",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Marshal(tc.value)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			assert.Equal(t, tc.expected, o.String())
+		})
+	}
+}
+
+func TestUnmarshal_Time_Interface(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(
+		`(lambda d: [d.datetime(2024, 3, 5), d.date(2024, 3, 5), d.time(6, 7), d.timedelta(seconds=1)])(__import__("datetime"))`,
+		nil, nil,
+	)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	values, err := python3.UnmarshalAs[[]any](o)
+	require.NoError(t, err)
+
+	expected := []any{
+		time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		python3.Date{Year: 2024, Month: time.March, Day: 5},
+		python3.TimeOfDay{Hour: 6, Minute: 7},
+		time.Second,
+	}
+
+	assert.Equal(t, expected, values)
+}
+
+func TestUnmarshal_Time_TypeError(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario      string
+		expr          string
+		target        any
+		expectedError string
+	}{
+		{
+			scenario:      "date into time",
+			expr:          `date(2024, 3, 5)`,
+			target:        new(time.Time),
+			expectedError: "python3: cannot unmarshal date into Go value of type time.Time",
+		},
+		{
+			scenario:      "datetime into date",
+			expr:          `datetime(2024, 3, 5)`,
+			target:        new(python3.Date),
+			expectedError: "python3: cannot unmarshal datetime into Go value of type python.Date",
+		},
+		{
+			scenario:      "datetime into time of day",
+			expr:          `datetime(2024, 3, 5)`,
+			target:        new(python3.TimeOfDay),
+			expectedError: "python3: cannot unmarshal datetime into Go value of type python.TimeOfDay",
+		},
+		{
+			scenario:      "float into duration",
+			expr:          `MAXYEAR / 2`,
+			target:        new(time.Duration),
+			expectedError: "python3: cannot unmarshal float into Go value of type time.Duration",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			err := python3.Unmarshal(evalDateTime(t, tc.expr), tc.target)
+
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
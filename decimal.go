@@ -0,0 +1,58 @@
+package python
+
+import "reflect"
+
+var decimalType = reflect.TypeFor[Decimal]()
+
+// Decimal is the exact string representation of a Python decimal.Decimal, such as "1.10", "-0", "1E+2" or "NaN". It
+// round-trips through Marshal and Unmarshal without loss of precision.
+//
+// A Python decimal.Decimal can also be unmarshaled into any type that implements encoding.TextUnmarshaler, such as
+// *big.Rat or the decimal types of third-party packages. To marshal such a type as a decimal.Decimal, implement
+// DecimalMarshaler.
+type Decimal string
+
+// DecimalMarshaler is the interface implemented by types that can marshal themselves into a Python decimal.Decimal,
+// such as the adapters of the decimal types of third-party packages. MarshalDecimal returns the string representation
+// of the decimal.
+type DecimalMarshaler interface {
+	MarshalDecimal() (string, error)
+}
+
+// IsDecimal returns true if o is a Python decimal.Decimal object.
+func IsDecimal(o PyObjector) bool {
+	return isInstanceOf(o, "decimal", "Decimal")
+}
+
+// NewDecimal creates a new Python decimal.Decimal object from its string representation.
+func NewDecimal(d Decimal) (*Object, error) {
+	decimal, err := ImportModule("decimal")
+	if err != nil {
+		return nil, err
+	}
+
+	return decimal.TryCallMethod("Decimal", string(d))
+}
+
+// String returns the string representation of the decimal.
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// marshalDecimal converts a DecimalMarshaler to a Python decimal.Decimal.
+func marshalDecimal(m DecimalMarshaler) (*Object, error) {
+	s, err := m.MarshalDecimal()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDecimal(Decimal(s))
+}
+
+func unmarshalDecimal(o *Object) (Decimal, error) {
+	if !IsDecimal(o) {
+		return "", &UnmarshalTypeError{Value: TypeName(o), Type: decimalType}
+	}
+
+	return Decimal(o.String()), nil
+}
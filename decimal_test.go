@@ -0,0 +1,259 @@
+package python_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+// cents is a fixed-point decimal that unmarshals itself from the string representation of a Python decimal.
+type cents int64
+
+func (c *cents) UnmarshalText(text []byte) error {
+	units, fraction, _ := strings.Cut(string(text), ".")
+
+	if len(fraction) != 2 {
+		return errors.New("cents: need two decimal places") //nolint: err113
+	}
+
+	v, ok := new(big.Int).SetString(units+fraction, 10)
+	if !ok || !v.IsInt64() {
+		return errors.New("cents: invalid amount") //nolint: err113
+	}
+
+	*c = cents(v.Int64())
+
+	return nil
+}
+
+func TestDecimal(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		value    python3.Decimal
+	}{
+		{scenario: "trailing zeros", value: "1.10"},
+		{scenario: "negative zero", value: "-0"},
+		{scenario: "exponent", value: "1E+2"},
+		{scenario: "precise", value: "0.1000000000000000000000000000000000000001"},
+		{scenario: "nan", value: "NaN"},
+		{scenario: "infinity", value: "-Infinity"},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.NewDecimal(tc.value)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			assert.True(t, python3.IsDecimal(o))
+			assert.Equal(t, tc.value.String(), o.String())
+
+			actual, err := python3.UnmarshalAs[python3.Decimal](o)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.value, actual)
+		})
+	}
+}
+
+func TestNewDecimal_Invalid(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.NewDecimal("1,5")
+
+	assert.Nil(t, o)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decimal.ConversionSyntax")
+}
+
+func TestIsDecimal(t *testing.T) {
+	withGIL(t)
+
+	assert.False(t, python3.IsDecimal(python3.NewFloat64(1.5)))
+	assert.False(t, python3.IsDecimal(python3.NewString("1.5")))
+}
+
+func TestMarshal_Decimal(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Marshal(map[string]python3.Decimal{"total": "19.99"})
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	total := o.GetItem("total")
+	defer total.DecRef()
+
+	assert.True(t, python3.IsDecimal(total))
+	assert.Equal(t, "19.99", total.String())
+}
+
+// amount is a fixed-point decimal struct that marshals itself as a Python decimal, like an adapter of the decimal types
+// of third-party packages.
+type amount struct {
+	units int64
+	cents int64
+}
+
+func (a amount) MarshalDecimal() (string, error) {
+	if a.cents < 0 || a.cents > 99 {
+		return "", errors.New("amount: invalid cents") //nolint: err113
+	}
+
+	return fmt.Sprintf("%d.%02d", a.units, a.cents), nil
+}
+
+func TestMarshal_DecimalMarshaler(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		value    any
+		expected string
+	}{
+		{
+			scenario: "value",
+			value:    amount{units: 12, cents: 50},
+			expected: "12.50",
+		},
+		{
+			scenario: "pointer",
+			value:    &amount{units: 7, cents: 5},
+			expected: "7.05",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Marshal(tc.value)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			assert.True(t, python3.IsDecimal(o))
+			assert.Equal(t, tc.expected, o.String())
+		})
+	}
+}
+
+func TestMarshal_DecimalMarshaler_Field(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Marshal(struct {
+		Total amount `python:"total"`
+	}{Total: amount{units: 19, cents: 99}})
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	total := o.GetItem("total")
+	defer total.DecRef()
+
+	assert.True(t, python3.IsDecimal(total))
+	assert.Equal(t, "19.99", total.String())
+}
+
+func TestMarshal_DecimalMarshaler_Error(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Marshal(amount{units: 1, cents: 100})
+
+	assert.Nil(t, o)
+	require.EqualError(t, err, "amount: invalid cents")
+}
+
+func TestMarshal_TextMarshaler_NotDecimal(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Marshal(struct {
+		Addr netip.Addr `python:"addr"`
+	}{Addr: netip.MustParseAddr("127.0.0.1")})
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	addr := o.GetItem("addr")
+	defer addr.DecRef()
+
+	assert.False(t, python3.IsDecimal(addr))
+}
+
+func TestUnmarshal_Decimal(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`__import__("decimal").Decimal("12.50")`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	run(t, "string", func(t *testing.T) {
+		actual, err := python3.UnmarshalAs[string](o)
+		require.NoError(t, err)
+
+		assert.Equal(t, "12.50", actual)
+	})
+
+	run(t, "any", func(t *testing.T) {
+		actual, err := python3.UnmarshalAs[any](o)
+		require.NoError(t, err)
+
+		assert.Equal(t, python3.Decimal("12.50"), actual)
+	})
+
+	run(t, "text unmarshaler", func(t *testing.T) {
+		actual, err := python3.UnmarshalAs[cents](o)
+		require.NoError(t, err)
+
+		assert.Equal(t, cents(1250), actual)
+	})
+
+	run(t, "big.Rat", func(t *testing.T) {
+		actual, err := python3.UnmarshalAs[*big.Rat](o)
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewRat(25, 2), actual)
+	})
+
+	run(t, "float", func(t *testing.T) {
+		actual, err := python3.UnmarshalAs[float64](o)
+
+		assert.Zero(t, actual)
+		require.EqualError(t, err, "python3: cannot unmarshal Decimal into Go value of type float64")
+	})
+}
+
+func TestUnmarshal_Decimal_TextUnmarshalerError(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.NewDecimal("12.5")
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	_, err = python3.UnmarshalAs[cents](o)
+
+	require.EqualError(t, err, "cents: need two decimal places")
+}
+
+func TestUnmarshal_Decimal_NotDecimal(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewString("12.50")
+	defer o.DecRef()
+
+	actual, err := python3.UnmarshalAs[python3.Decimal](o)
+
+	assert.Empty(t, actual)
+	require.EqualError(t, err, "python3: cannot unmarshal str into Go value of type python.Decimal")
+}
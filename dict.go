@@ -0,0 +1,276 @@
+package python
+
+import (
+	"reflect"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// IsDict returns true if the object is a dict.
+func IsDict(o PyObjector) bool {
+	return o.PyObject().Type() == cpy3.Dict
+}
+
+// DictObject is a generic Python dict.
+type DictObject cpy3.PyObject
+
+// DecRef decreases the reference count of the object.
+func (o *DictObject) DecRef() {
+	if o == nil {
+		return
+	}
+
+	(*cpy3.PyObject)(o).DecRef()
+}
+
+// PyObject returns the underlying PyObject.
+func (o *DictObject) PyObject() *cpy3.PyObject {
+	return (*cpy3.PyObject)(o)
+}
+
+// Length returns the number of items in the dict.
+func (o *DictObject) Length() int {
+	return cpy3.PyDict_Size((*cpy3.PyObject)(o))
+}
+
+// Set sets the value of key.
+func (o *DictObject) Set(key, value any) {
+	defer MustSuccess()
+
+	pyKey, releaseKey := borrowPyObject(key)
+	defer releaseKey()
+
+	pyValue, releaseValue := borrowPyObject(value)
+	defer releaseValue()
+
+	cpy3.PyDict_SetItem((*cpy3.PyObject)(o), pyKey, pyValue)
+}
+
+// Get returns the value of key, or nil if the dict does not contain the key.
+func (o *DictObject) Get(key any) *Object {
+	pyKey, release := borrowPyObject(key)
+	defer release()
+
+	item := cpy3.PyDict_GetItemWithError((*cpy3.PyObject)(o), pyKey)
+
+	MustSuccess()
+
+	return NewObject(item)
+}
+
+// Delete removes key from the dict. It does nothing if the dict does not contain the key.
+func (o *DictObject) Delete(key any) {
+	if !o.Has(key) {
+		return
+	}
+
+	defer MustSuccess()
+
+	pyKey, release := borrowPyObject(key)
+	defer release()
+
+	cpy3.PyDict_DelItem((*cpy3.PyObject)(o), pyKey)
+}
+
+// Has returns true if the dict contains key.
+func (o *DictObject) Has(key any) bool {
+	defer MustSuccess()
+
+	pyKey, release := borrowPyObject(key)
+	defer release()
+
+	return cpy3.PyDict_Contains((*cpy3.PyObject)(o), pyKey) == 1
+}
+
+// Keys returns a list of all the keys in the dict.
+func (o *DictObject) Keys() *ListObject {
+	return (*ListObject)(cpy3.PyDict_Keys((*cpy3.PyObject)(o)))
+}
+
+// Values returns a list of all the values in the dict.
+func (o *DictObject) Values() *ListObject {
+	return (*ListObject)(cpy3.PyDict_Values((*cpy3.PyObject)(o)))
+}
+
+// Items returns a list of all the (key, value) tuples in the dict.
+func (o *DictObject) Items() *ListObject {
+	return (*ListObject)(cpy3.PyDict_Items((*cpy3.PyObject)(o)))
+}
+
+// AsObject returns the dict as Object.
+func (o *DictObject) AsObject() *Object {
+	return (*Object)(o)
+}
+
+// String returns the string representation of the object.
+func (o *DictObject) String() string {
+	return asString((*cpy3.PyObject)(o))
+}
+
+// forEach calls fn for every key-value pair in the dict. The key and value are borrowed references.
+func (o *DictObject) forEach(fn func(key, value *Object)) {
+	var (
+		pos        int
+		key, value *cpy3.PyObject
+	)
+
+	for cpy3.PyDict_Next((*cpy3.PyObject)(o), &pos, &key, &value) {
+		fn(NewObject(key), NewObject(value))
+	}
+}
+
+// NewDictObject creates a new dict.
+func NewDictObject() *DictObject {
+	return (*DictObject)(cpy3.PyDict_New())
+}
+
+// DictItem is a key-value pair of a dict.
+type DictItem[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Dict is a generic Python dict.
+type Dict[K comparable, V any] struct {
+	obj *DictObject
+}
+
+// UnmarshalPyObject unmarshals a Python object to the dict.
+func (d *Dict[K, V]) UnmarshalPyObject(o *Object) error {
+	if !IsDict(o) {
+		return &UnmarshalTypeError{Value: TypeName(o), Type: reflect.TypeOf(d)}
+	}
+
+	d.obj = (*DictObject)(o)
+
+	return nil
+}
+
+// DecRef decreases the reference count of the object.
+func (d *Dict[K, V]) DecRef() {
+	if d == nil {
+		return
+	}
+
+	d.obj.DecRef()
+}
+
+// PyObject returns the underlying PyObject.
+func (d *Dict[K, V]) PyObject() *cpy3.PyObject {
+	return d.obj.PyObject()
+}
+
+// Length returns the number of items in the dict.
+func (d *Dict[K, V]) Length() int {
+	return d.obj.Length()
+}
+
+// Set sets the value of key.
+func (d *Dict[K, V]) Set(key K, value V) {
+	d.obj.Set(key, value)
+}
+
+// Get returns the value of key and whether the dict contains the key.
+func (d *Dict[K, V]) Get(key K) (V, bool) {
+	o := d.obj.Get(key)
+	if o == nil {
+		var v V
+
+		return v, false
+	}
+
+	return MustUnmarshalAs[V](o), true
+}
+
+// Delete removes key from the dict.
+func (d *Dict[K, V]) Delete(key K) {
+	d.obj.Delete(key)
+}
+
+// Has returns true if the dict contains key.
+func (d *Dict[K, V]) Has(key K) bool {
+	return d.obj.Has(key)
+}
+
+// Keys returns all the keys in the dict.
+func (d *Dict[K, V]) Keys() []K {
+	keys := make([]K, 0, d.Length())
+
+	d.obj.forEach(func(key, _ *Object) {
+		keys = append(keys, MustUnmarshalAs[K](key))
+	})
+
+	return keys
+}
+
+// Values returns all the values in the dict.
+func (d *Dict[K, V]) Values() []V {
+	values := make([]V, 0, d.Length())
+
+	d.obj.forEach(func(_, value *Object) {
+		values = append(values, MustUnmarshalAs[V](value))
+	})
+
+	return values
+}
+
+// Items returns all the key-value pairs in the dict.
+func (d *Dict[K, V]) Items() []DictItem[K, V] {
+	items := make([]DictItem[K, V], 0, d.Length())
+
+	d.obj.forEach(func(key, value *Object) {
+		items = append(items, DictItem[K, V]{
+			Key:   MustUnmarshalAs[K](key),
+			Value: MustUnmarshalAs[V](value),
+		})
+	})
+
+	return items
+}
+
+// AsObject returns the dict as Object.
+func (d *Dict[K, V]) AsObject() *Object {
+	return d.obj.AsObject()
+}
+
+// AsMap converts the dict to a map.
+func (d *Dict[K, V]) AsMap() map[K]V {
+	m := make(map[K]V, d.Length())
+
+	d.obj.forEach(func(key, value *Object) {
+		m[MustUnmarshalAs[K](key)] = MustUnmarshalAs[V](value)
+	})
+
+	return m
+}
+
+// String returns the string representation of the object.
+func (d *Dict[K, V]) String() string {
+	return d.obj.String()
+}
+
+// AnyDict is a Python dict.
+type AnyDict = Dict[any, any]
+
+// NewDict creates a new dict.
+func NewDict() *AnyDict {
+	return NewDictForType[any, any]()
+}
+
+// NewDictForType creates a new dict for the given key and value types.
+func NewDictForType[K comparable, V any]() *Dict[K, V] {
+	return &Dict[K, V]{
+		obj: NewDictObject(),
+	}
+}
+
+// NewDictFromMap converts a map to a dict.
+func NewDictFromMap[K comparable, V any](m map[K]V) *Dict[K, V] {
+	dict := NewDictForType[K, V]()
+
+	for k, v := range m {
+		dict.Set(k, v)
+	}
+
+	return dict
+}
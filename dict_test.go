@@ -0,0 +1,211 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cpy3 "go.nhat.io/cpy/v3"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestDict_DecRefNil(t *testing.T) {
+	withGIL(t)
+
+	var dict *python3.AnyDict
+
+	assert.NotPanics(t, func() {
+		dict.DecRef()
+	})
+}
+
+func TestDict_IsDict(t *testing.T) {
+	withGIL(t)
+
+	assert.True(t, python3.IsDict(python3.NewDict()))
+	assert.False(t, python3.IsDict(python3.NewList(10)))
+	assert.False(t, python3.IsDict(python3.NewTuple(10)))
+	assert.False(t, python3.IsDict(python3.NewBool(true)))
+	assert.False(t, python3.IsDict(python3.NewInt(42)))
+	assert.False(t, python3.IsDict(python3.NewString("hello")))
+	assert.False(t, python3.IsDict(python3.NewFloat64(3.14)))
+}
+
+func TestDict_Empty(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDict()
+	defer dict.DecRef()
+
+	assert.True(t, cpy3.PyDict_CheckExact(dict.PyObject()))
+	assert.Equal(t, 0, dict.Length())
+	assert.Equal(t, `{}`, dict.String())
+}
+
+func TestDict_SetGet(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDictForType[string, int]()
+	defer dict.DecRef()
+
+	dict.Set("one", 1)
+	dict.Set("two", 2)
+
+	assert.Equal(t, 2, dict.Length())
+	assert.True(t, dict.Has("one"))
+	assert.False(t, dict.Has("three"))
+
+	actual, ok := dict.Get("two")
+
+	assert.True(t, ok)
+	assert.Equal(t, 2, actual)
+
+	actual, ok = dict.Get("three")
+
+	assert.False(t, ok)
+	assert.Equal(t, 0, actual)
+
+	assert.Equal(t, `{'one': 1, 'two': 2}`, dict.String())
+}
+
+func TestDict_Delete(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDictFromMap(map[string]int{"one": 1, "two": 2})
+	defer dict.DecRef()
+
+	dict.Delete("one")
+	dict.Delete("three")
+
+	assert.Equal(t, 1, dict.Length())
+	assert.False(t, dict.Has("one"))
+	assert.True(t, dict.Has("two"))
+}
+
+func TestDict_UnhashableKey(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDict()
+	defer dict.DecRef()
+
+	assert.Panics(t, func() {
+		dict.Set([]int{1}, 1)
+	})
+}
+
+func TestDict_KeysValuesItems(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDictForType[string, int]()
+	defer dict.DecRef()
+
+	dict.Set("one", 1)
+	dict.Set("two", 2)
+	dict.Set("three", 3)
+
+	assert.Equal(t, []string{"one", "two", "three"}, dict.Keys())
+	assert.Equal(t, []int{1, 2, 3}, dict.Values())
+
+	expected := []python3.DictItem[string, int]{
+		{Key: "one", Value: 1},
+		{Key: "two", Value: 2},
+		{Key: "three", Value: 3},
+	}
+
+	assert.Equal(t, expected, dict.Items())
+}
+
+func TestDictObject_KeysValuesItems(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDictObject()
+	defer dict.DecRef()
+
+	dict.Set("one", 1)
+	dict.Set(2, "two")
+
+	keys := dict.Keys()
+	defer keys.DecRef()
+
+	values := dict.Values()
+	defer values.DecRef()
+
+	items := dict.Items()
+	defer items.DecRef()
+
+	assert.Equal(t, `['one', 2]`, keys.String())
+	assert.Equal(t, `[1, 'two']`, values.String())
+	assert.Equal(t, `[('one', 1), (2, 'two')]`, items.String())
+}
+
+func TestNewDictFromMap(t *testing.T) {
+	withGIL(t)
+
+	expected := map[string]float64{"pi": 3.14, "e": 2.72}
+
+	dict := python3.NewDictFromMap(expected)
+	defer dict.DecRef()
+
+	assert.Equal(t, 2, dict.Length())
+	assert.Equal(t, expected, dict.AsMap())
+}
+
+func TestDict_AnyValues(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDict()
+	defer dict.DecRef()
+
+	dict.Set("int", 1)
+	dict.Set(2, "hello")
+	dict.Set(3.14, true)
+
+	expected := map[any]any{
+		"int":    int64(1),
+		int64(2): "hello",
+		3.14:     true,
+	}
+
+	assert.Equal(t, expected, dict.AsMap())
+}
+
+func TestDictOfList(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDictForType[string, *python3.List[int]]()
+	defer dict.DecRef()
+
+	dict.Set("numbers", python3.NewListFromValues(1, 2, 3))
+
+	assert.Equal(t, `{'numbers': [1, 2, 3]}`, dict.String())
+
+	actual, ok := dict.Get("numbers")
+
+	require.True(t, ok)
+	assert.Equal(t, []int{1, 2, 3}, actual.AsSlice())
+}
+
+func TestDict_UnmarshalPyObject(t *testing.T) {
+	withGIL(t)
+
+	expected := python3.NewDictFromMap(map[string]int{"one": 1})
+	defer expected.DecRef()
+
+	var dict python3.Dict[string, int]
+
+	err := python3.Unmarshal(expected.AsObject(), &dict)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"one": 1}, dict.AsMap())
+}
+
+func TestDict_UnmarshalPyObject_Error(t *testing.T) {
+	withGIL(t)
+
+	var dict python3.Dict[string, int]
+
+	err := python3.Unmarshal(python3.NewListFromValues(1, 2).AsObject(), &dict)
+
+	require.EqualError(t, err, `python3: cannot unmarshal list into Go value of type *python.Dict[string,int]`)
+}
@@ -1,10 +1,89 @@
 package python
 
-import cpy3 "go.nhat.io/cpy/v3"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
 
 // Exception is a Python exception.
+//
+// All the other exceptions embed Exception, so errors.As with an *Exception target matches any Python exception, except
+// the ones that do not derive from Exception in Python, such as KeyboardInterrupt, which embed BaseException instead.
+//
+// The exceptions are comparable with ==. The ones returned by LastError keep the original Python exception, see Object.
 type Exception struct { //nolint: errname,stylecheck
 	Message string
+
+	// Type is the qualified name of the type of the exception, such as "KeyError" or "json.decoder.JSONDecodeError".
+	Type string
+	// Cause is the exception set by "raise ... from ...".
+	Cause error
+	// Context is the exception that was being handled when the exception was raised, unless it is suppressed by
+	// "raise ... from ...".
+	Context error
+
+	// details is shared by the copies of the exception, so that the exception stays comparable.
+	details *exceptionDetails
+}
+
+type exceptionDetails struct {
+	traceback []Frame
+	notes     []string
+	object    *Managed[*Object]
+}
+
+// Frame is a frame of a Python traceback.
+type Frame struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// Traceback returns the stack where the exception was raised, the most recent call last.
+func (e Exception) Traceback() []Frame {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.traceback
+}
+
+// Notes returns the notes added to the exception with add_note().
+func (e Exception) Notes() []string {
+	if e.details == nil {
+		return nil
+	}
+
+	return e.details.notes
+}
+
+// Object returns the original Python exception, or nil if the exception was not returned by LastError or is released.
+// The exception owns the reference, which is released by Release, or when the exception is garbage collected, see
+// Managed.
+func (e Exception) Object() *Object {
+	if e.details == nil || e.details.object == nil {
+		return nil
+	}
+
+	return e.details.object.Get()
+}
+
+// Release releases the original Python exceptions of the exception, its cause and its context right away, instead of
+// waiting for the garbage collector. The GIL must be held.
+func (e Exception) Release() {
+	if e.details != nil && e.details.object != nil {
+		e.details.object.Release()
+	}
+
+	for _, err := range e.Unwrap() {
+		if r, ok := err.(interface{ Release() }); ok {
+			r.Release()
+		}
+	}
 }
 
 // Error returns a string representation of the Exception.
@@ -12,6 +91,157 @@ func (e Exception) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the cause and the context of the exception.
+func (e Exception) Unwrap() []error {
+	errs := make([]error, 0, 2)
+
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+
+	if e.Context != nil {
+		errs = append(errs, e.Context)
+	}
+
+	return errs
+}
+
+// Format implements fmt.Formatter. The %+v verb prints the full Python traceback, including the chain of causes and
+// contexts.
+func (e Exception) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, e.traceback())
+
+			return
+		}
+
+		_, _ = io.WriteString(s, e.Message)
+
+	case 's':
+		_, _ = io.WriteString(s, e.Message)
+
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Message)
+	}
+}
+
+// traceback formats the exception like the Python traceback module.
+func (e Exception) traceback() string {
+	var sb strings.Builder
+
+	switch {
+	case e.Cause != nil:
+		_, _ = fmt.Fprintf(&sb, "%+v\n\nThe above exception was the direct cause of the following exception:\n\n", e.Cause)
+
+	case e.Context != nil:
+		_, _ = fmt.Fprintf(&sb, "%+v\n\nDuring handling of the above exception, another exception occurred:\n\n", e.Context)
+	}
+
+	if traceback := e.Traceback(); len(traceback) > 0 {
+		sb.WriteString("Traceback (most recent call last):\n")
+
+		for _, f := range traceback {
+			_, _ = fmt.Fprintf(&sb, "  File %q, line %d, in %s\n", f.File, f.Line, f.Function)
+		}
+	}
+
+	sb.WriteString(e.Type)
+
+	if e.Message != "" {
+		if e.Type != "" {
+			sb.WriteString(": ")
+		}
+
+		sb.WriteString(e.Message)
+	}
+
+	for _, note := range e.Notes() {
+		sb.WriteString("\n")
+		sb.WriteString(note)
+	}
+
+	return sb.String()
+}
+
+// exception returns the Exception embedded in the exceptions of this package.
+func (e Exception) exception() Exception {
+	return e
+}
+
+// As finds the first error in err's tree that matches target.
+func (e Exception) As(target any) bool {
+	switch t := target.(type) {
+	case *Exception:
+		*t = e
+
+		return true
+
+	case *BaseException:
+		*t = BaseException{Exception: e}
+
+		return true
+	}
+
+	return false
+}
+
+// BaseException is a Python exception that does not derive from Exception, such as KeyboardInterrupt or SystemExit, so
+// that it is not caught by code that catches Exception. errors.As with a *BaseException target matches any Python
+// exception, but errors.As with an *Exception target does not match a BaseException.
+type BaseException struct { //nolint: errname
+	Exception
+}
+
+// As finds the first error in err's tree that matches target.
+func (e BaseException) As(target any) bool {
+	if t, ok := target.(*BaseException); ok {
+		*t = e
+
+		return true
+	}
+
+	return false
+}
+
+// ArithmeticError is the base class for those built-in exceptions that are raised for various arithmetic errors:
+// OverflowError, ZeroDivisionError.
+type ArithmeticError struct {
+	Exception
+}
+
+// As finds the first error in err's tree that matches target.
+func (e ArithmeticError) As(target any) bool {
+	if t, ok := target.(*ArithmeticError); ok {
+		*t = e
+
+		return true
+	}
+
+	return e.Exception.As(target)
+}
+
+// OverflowError is returned when the result of an arithmetic operation is too large to be represented.
+type OverflowError struct {
+	ArithmeticError
+}
+
+// ZeroDivisionError is returned when the second argument of a division or modulo operation is zero.
+type ZeroDivisionError struct {
+	ArithmeticError
+}
+
+// AssertionError is returned when an assert statement fails.
+type AssertionError struct {
+	Exception
+}
+
+// AttributeError is returned when an attribute reference or assignment fails.
+type AttributeError struct {
+	Exception
+}
+
 // ImportError is returned when a Python module cannot be imported.
 type ImportError struct {
 	Exception
@@ -20,16 +250,152 @@ type ImportError struct {
 	Path   string
 }
 
+// As finds the first error in err's tree that matches target.
+func (e ImportError) As(target any) bool {
+	if t, ok := target.(*ImportError); ok {
+		*t = e
+
+		return true
+	}
+
+	return e.Exception.As(target)
+}
+
 // ModuleNotFoundError is returned when a Python module cannot be found.
 type ModuleNotFoundError struct {
 	ImportError
 }
 
+// LookupError is the base class for the exceptions that are raised when a key or index used on a mapping or sequence
+// is invalid: IndexError, KeyError.
+type LookupError struct {
+	Exception
+}
+
+// As finds the first error in err's tree that matches target.
+func (e LookupError) As(target any) bool {
+	if t, ok := target.(*LookupError); ok {
+		*t = e
+
+		return true
+	}
+
+	return e.Exception.As(target)
+}
+
 // IndexError is returned when a sequence subscript is out of range.
 type IndexError struct {
+	LookupError
+}
+
+// KeyError is returned when a mapping (dictionary) key is not found in the set of existing keys.
+type KeyError struct {
+	LookupError
+}
+
+// MemoryError is returned when an operation runs out of memory.
+type MemoryError struct {
+	Exception
+}
+
+// NameError is returned when a local or global name is not found.
+type NameError struct {
+	Exception
+}
+
+// OSError is returned when a system function returns a system-related error, including I/O failures such as "file not
+// found" or "disk full".
+type OSError struct {
+	Exception
+
+	Errno    int
+	Strerror string
+	Filename string
+}
+
+// As finds the first error in err's tree that matches target.
+func (e OSError) As(target any) bool {
+	if t, ok := target.(*OSError); ok {
+		*t = e
+
+		return true
+	}
+
+	return e.Exception.As(target)
+}
+
+// FileNotFoundError is returned when a file or directory is requested but does not exist.
+type FileNotFoundError struct {
+	OSError
+}
+
+// PermissionError is returned when trying to run an operation without the adequate access rights.
+type PermissionError struct {
+	OSError
+}
+
+// TimeoutError is returned when a system function timed out at the system level.
+type TimeoutError struct {
+	OSError
+}
+
+// RuntimeError is returned when an error is detected that doesn't fall in any of the other categories.
+type RuntimeError struct {
 	Exception
 }
 
+// As finds the first error in err's tree that matches target.
+func (e RuntimeError) As(target any) bool {
+	if t, ok := target.(*RuntimeError); ok {
+		*t = e
+
+		return true
+	}
+
+	return e.Exception.As(target)
+}
+
+// NotImplementedError is returned when an abstract method is not implemented.
+type NotImplementedError struct {
+	RuntimeError
+}
+
+// RecursionError is returned when the maximum recursion depth is exceeded.
+type RecursionError struct {
+	RuntimeError
+}
+
+// StopIteration is returned when an iterator has no further items.
+type StopIteration struct {
+	Exception
+}
+
+// SyntaxError is returned when the parser encounters a syntax error, including the indentation errors.
+type SyntaxError struct {
+	Exception
+
+	Filename string
+	Line     int
+	Offset   int
+	Text     string
+}
+
+// TypeError is returned when an operation or function is applied to an object of inappropriate type.
+type TypeError struct {
+	Exception
+}
+
+// ValueError is returned when an operation or function receives an argument that has the right type but an
+// inappropriate value.
+type ValueError struct {
+	Exception
+}
+
+// KeyboardInterrupt is returned when the user hits the interrupt key.
+type KeyboardInterrupt struct {
+	BaseException
+}
+
 // MustSuccess panics if the last Python operation failed.
 func MustSuccess() {
 	if err := LastError(); err != nil {
@@ -45,20 +411,295 @@ func LastError() error {
 	}
 
 	defer ClearError()
-	defer pExec.DecRef()
+
+	return newError(pExec, map[*cpy3.PyObject]bool{})
+}
+
+// SetError raises err as a Python exception.
+//
+// The exceptions of this package, wrapped or not, are raised as the matching Python exceptions, or re-raised as is if
+// they came from LastError and are not released. The other errors are raised as the default error type, see
+// SetDefaultErrorType, and LastError returns the original Go error when the exception comes back.
+func SetError(err error) {
+	if err == nil {
+		return
+	}
+
+	var exc exception
+	if !errors.As(err, &exc) {
+		setGoError(err)
+
+		return
+	}
+
+	if o := exc.exception().Object(); o != nil {
+		o.PyObject().IncRef()
+		cpy3.PyErr_SetRaisedException(o.PyObject())
+
+		return
+	}
+
+	class, ok := exceptionClass(exc)
+	if !ok {
+		setGoError(err)
+
+		return
+	}
+
+	switch err := exc.(type) {
+	case ImportError:
+		setImportError(class, err)
+
+	case ModuleNotFoundError:
+		setImportError(class, err.ImportError)
+
+	case OSError:
+		setOSError(class, err)
+
+	case FileNotFoundError:
+		setOSError(class, err.OSError)
+
+	case PermissionError:
+		setOSError(class, err.OSError)
+
+	case TimeoutError:
+		setOSError(class, err.OSError)
+
+	case SyntaxError:
+		raiseInstance(class, []any{err.Message, []any{err.Filename, err.Line, err.Offset, err.Text}}, nil)
+
+	default:
+		cpy3.PyErr_SetString(class, exc.exception().Message)
+	}
+}
+
+// exception is implemented by the exceptions of this package, which all embed Exception.
+type exception interface {
+	error
+	exception() Exception
+}
+
+// exceptionClass returns the Python exception class of an exception of this package. It returns false for the other
+// types that embed Exception.
+func exceptionClass(err exception) (*cpy3.PyObject, bool) { //nolint: cyclop,funlen,gocyclo
+	switch err.(type) {
+	case BaseException:
+		return cpy3.PyExc_BaseException, true
+
+	case Exception:
+		return cpy3.PyExc_Exception, true
+
+	case ArithmeticError:
+		return cpy3.PyExc_ArithmeticError, true
+
+	case OverflowError:
+		return cpy3.PyExc_OverflowError, true
+
+	case ZeroDivisionError:
+		return cpy3.PyExc_ZeroDivisionError, true
+
+	case AssertionError:
+		return cpy3.PyExc_AssertionError, true
+
+	case AttributeError:
+		return cpy3.PyExc_AttributeError, true
+
+	case ImportError:
+		return cpy3.PyExc_ImportError, true
+
+	case ModuleNotFoundError:
+		return cpy3.PyExc_ModuleNotFoundError, true
+
+	case LookupError:
+		return cpy3.PyExc_LookupError, true
+
+	case IndexError:
+		return cpy3.PyExc_IndexError, true
+
+	case KeyError:
+		return cpy3.PyExc_KeyError, true
+
+	case MemoryError:
+		return cpy3.PyExc_MemoryError, true
+
+	case NameError:
+		return cpy3.PyExc_NameError, true
+
+	case OSError:
+		return cpy3.PyExc_OSError, true
+
+	case FileNotFoundError:
+		return cpy3.PyExc_FileNotFoundError, true
+
+	case PermissionError:
+		return cpy3.PyExc_PermissionError, true
+
+	case TimeoutError:
+		return cpy3.PyExc_TimeoutError, true
+
+	case RuntimeError:
+		return cpy3.PyExc_RuntimeError, true
+
+	case NotImplementedError:
+		return cpy3.PyExc_NotImplementedError, true
+
+	case RecursionError:
+		return cpy3.PyExc_RecursionError, true
+
+	case StopIteration:
+		return cpy3.PyExc_StopIteration, true
+
+	case SyntaxError:
+		return cpy3.PyExc_SyntaxError, true
+
+	case TypeError:
+		return cpy3.PyExc_TypeError, true
+
+	case ValueError:
+		return cpy3.PyExc_ValueError, true
+
+	case KeyboardInterrupt:
+		return cpy3.PyExc_KeyboardInterrupt, true
+	}
+
+	return nil, false
+}
+
+// raiseInstance raises an instance of the class created with the arguments.
+func raiseInstance(class *cpy3.PyObject, args []any, kwargs map[string]any) {
+	exc, err := NewObject(class).CallKw(args, kwargs)
+	if err != nil {
+		SetError(err)
+
+		return
+	}
+
+	defer exc.DecRef()
+
+	cpy3.PyErr_SetObject(class, exc.PyObject())
+}
+
+func setImportError(class *cpy3.PyObject, err ImportError) {
+	kwargs := map[string]any{}
+
+	if err.Module != "" {
+		kwargs["name"] = err.Module
+	}
+
+	if err.Path != "" {
+		kwargs["path"] = err.Path
+	}
+
+	raiseInstance(class, []any{err.Message}, kwargs)
+}
+
+func setOSError(class *cpy3.PyObject, err OSError) {
+	if err.Errno == 0 {
+		cpy3.PyErr_SetString(class, err.Message)
+
+		return
+	}
+
+	args := []any{err.Errno, err.Strerror}
+
+	if err.Filename != "" {
+		args = append(args, err.Filename)
+	}
+
+	raiseInstance(class, args, nil)
+}
+
+// newError converts a Python exception to the matching Go error. The error takes over the reference to the exception.
+// The seen exceptions are used to stop at the cycles in the chain of causes and contexts.
+func newError(o *Object, seen map[*cpy3.PyObject]bool) error { //nolint: cyclop,funlen,gocyclo
+	seen[o.PyObject()] = true
+
+	if err, ok := goErrorOf(o); ok {
+		o.DecRef()
+
+		return err
+	}
+
+	e := newException(o, seen)
 
 	switch {
-	case isException(pExec, cpy3.PyExc_ModuleNotFoundError):
-		return newErrModuleNotFound(pExec)
+	case isException(o, cpy3.PyExc_ModuleNotFoundError):
+		return ModuleNotFoundError{ImportError: newImportError(o, e)}
 
-	case isException(pExec, cpy3.PyExc_ImportError):
-		return newImportError(pExec)
+	case isException(o, cpy3.PyExc_ImportError):
+		return newImportError(o, e)
 
-	case isException(pExec, cpy3.PyExc_IndexError):
-		return IndexError{Exception: NewException(pExec.String())}
+	case isException(o, cpy3.PyExc_IndexError):
+		return IndexError{LookupError: LookupError{Exception: e}}
+
+	case isException(o, cpy3.PyExc_KeyError):
+		return KeyError{LookupError: LookupError{Exception: e}}
+
+	case isException(o, cpy3.PyExc_LookupError):
+		return LookupError{Exception: e}
+
+	case isException(o, cpy3.PyExc_OverflowError):
+		return OverflowError{ArithmeticError: ArithmeticError{Exception: e}}
+
+	case isException(o, cpy3.PyExc_ZeroDivisionError):
+		return ZeroDivisionError{ArithmeticError: ArithmeticError{Exception: e}}
+
+	case isException(o, cpy3.PyExc_ArithmeticError):
+		return ArithmeticError{Exception: e}
+
+	case isException(o, cpy3.PyExc_FileNotFoundError):
+		return FileNotFoundError{OSError: newOSError(o, e)}
+
+	case isException(o, cpy3.PyExc_PermissionError):
+		return PermissionError{OSError: newOSError(o, e)}
+
+	case isException(o, cpy3.PyExc_TimeoutError):
+		return TimeoutError{OSError: newOSError(o, e)}
+
+	case isException(o, cpy3.PyExc_OSError):
+		return newOSError(o, e)
+
+	case isException(o, cpy3.PyExc_NotImplementedError):
+		return NotImplementedError{RuntimeError: RuntimeError{Exception: e}}
+
+	case isException(o, cpy3.PyExc_RecursionError):
+		return RecursionError{RuntimeError: RuntimeError{Exception: e}}
+
+	case isException(o, cpy3.PyExc_RuntimeError):
+		return RuntimeError{Exception: e}
+
+	case isException(o, cpy3.PyExc_AssertionError):
+		return AssertionError{Exception: e}
+
+	case isException(o, cpy3.PyExc_AttributeError):
+		return AttributeError{Exception: e}
+
+	case isException(o, cpy3.PyExc_MemoryError):
+		return MemoryError{Exception: e}
+
+	case isException(o, cpy3.PyExc_NameError):
+		return NameError{Exception: e}
+
+	case isException(o, cpy3.PyExc_StopIteration):
+		return StopIteration{Exception: e}
+
+	case isException(o, cpy3.PyExc_SyntaxError):
+		return newSyntaxError(o, e)
+
+	case isException(o, cpy3.PyExc_TypeError):
+		return TypeError{Exception: e}
+
+	case isException(o, cpy3.PyExc_ValueError):
+		return ValueError{Exception: e}
+
+	case isException(o, cpy3.PyExc_KeyboardInterrupt):
+		return KeyboardInterrupt{BaseException: BaseException{Exception: e}}
+
+	case !isException(o, cpy3.PyExc_Exception):
+		return BaseException{Exception: e}
 	}
 
-	return NewException(pExec.String())
+	return e
 }
 
 // isException returns true if err is an instance of ex.
@@ -85,13 +726,79 @@ func NewException(message string) Exception {
 	return Exception{Message: message}
 }
 
-func newErrModuleNotFound(err *Object) ModuleNotFoundError {
-	return ModuleNotFoundError{
-		ImportError: newImportError(err),
+func newException(err *Object, seen map[*cpy3.PyObject]bool) Exception {
+	e := NewException(err.String())
+
+	e.Type = exceptionTypeName(err)
+
+	e.details = &exceptionDetails{
+		traceback: newTraceback(err),
+		notes:     exceptionNotes(err),
+		object:    Manage(err),
+	}
+
+	if cause := NewObject(cpy3.PyException_GetCause(err.PyObject())); cause != nil {
+		if seen[cause.PyObject()] {
+			cause.DecRef()
+		} else {
+			e.Cause = newError(cause, seen)
+		}
+	}
+
+	suppressContext := err.GetAttr("__suppress_context__")
+	defer suppressContext.DecRef()
+
+	if context := NewObject(cpy3.PyException_GetContext(err.PyObject())); context != nil {
+		if seen[context.PyObject()] || AsBool(suppressContext) {
+			context.DecRef()
+		} else {
+			e.Context = newError(context, seen)
+		}
+	}
+
+	return e
+}
+
+// exceptionTypeName returns the qualified name of the type of the exception, without the module for the built-in
+// exceptions.
+func exceptionTypeName(err *Object) string {
+	t := err.Type()
+	module := t.GetAttr("__module__")
+	name := t.GetAttr("__qualname__")
+
+	defer module.DecRef()
+	defer name.DecRef()
+
+	if !IsString(module) || module.String() == "builtins" {
+		return name.String()
+	}
+
+	return module.String() + "." + name.String()
+}
+
+// exceptionNotes returns the notes added to the exception with add_note().
+func exceptionNotes(err *Object) []string {
+	if !err.PyObject().HasAttrString("__notes__") {
+		return nil
+	}
+
+	notes := err.GetAttr("__notes__")
+	defer notes.DecRef()
+
+	if !IsList(notes) {
+		return nil
 	}
+
+	result := make([]string, notes.Length())
+
+	for i := range result {
+		result[i] = (*ListObject)(notes).Get(i).String()
+	}
+
+	return result
 }
 
-func newImportError(err *Object) ImportError {
+func newImportError(err *Object, e Exception) ImportError {
 	name := err.GetAttr("name")
 	path := err.GetAttr("path")
 	msg := err.GetAttr("msg")
@@ -100,9 +807,75 @@ func newImportError(err *Object) ImportError {
 	defer path.DecRef()
 	defer msg.DecRef()
 
+	e.Message = msg.String()
+
 	return ImportError{
-		Exception: NewException(msg.String()),
+		Exception: e,
 		Module:    name.String(),
 		Path:      path.String(),
 	}
 }
+
+func newOSError(err *Object, e Exception) OSError {
+	errno := err.GetAttr("errno")
+	strerror := err.GetAttr("strerror")
+	filename := err.GetAttr("filename")
+
+	defer errno.DecRef()
+	defer strerror.DecRef()
+	defer filename.DecRef()
+
+	osErr := OSError{Exception: e}
+
+	if IsInt(errno) {
+		osErr.Errno = AsInt(errno)
+	}
+
+	if IsString(strerror) {
+		osErr.Strerror = strerror.String()
+	}
+
+	if filename.PyObject() != cpy3.Py_None {
+		osErr.Filename = filename.String()
+	}
+
+	return osErr
+}
+
+func newSyntaxError(err *Object, e Exception) SyntaxError {
+	msg := err.GetAttr("msg")
+	filename := err.GetAttr("filename")
+	lineno := err.GetAttr("lineno")
+	offset := err.GetAttr("offset")
+	text := err.GetAttr("text")
+
+	defer msg.DecRef()
+	defer filename.DecRef()
+	defer lineno.DecRef()
+	defer offset.DecRef()
+	defer text.DecRef()
+
+	syntaxErr := SyntaxError{Exception: e}
+
+	if IsString(msg) {
+		syntaxErr.Message = msg.String()
+	}
+
+	if IsString(filename) {
+		syntaxErr.Filename = filename.String()
+	}
+
+	if IsInt(lineno) {
+		syntaxErr.Line = AsInt(lineno)
+	}
+
+	if IsInt(offset) {
+		syntaxErr.Offset = AsInt(offset)
+	}
+
+	if IsString(text) {
+		syntaxErr.Text = text.String()
+	}
+
+	return syntaxErr
+}
@@ -0,0 +1,741 @@
+package python_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cpy3 "go.nhat.io/cpy/v3"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+// comparableError returns a copy of err with only the exported fields, without the original Python exceptions, so that
+// it can be compared with the expected errors.
+func comparableError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Struct {
+		return err
+	}
+
+	return exportedFields(v).Interface().(error) //nolint: errcheck,forcetypeassert
+}
+
+func exportedFields(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+
+	for i := range v.NumField() {
+		f, field := v.Type().Field(i), v.Field(i)
+
+		switch {
+		case !f.IsExported():
+			continue
+
+		case f.Type.Kind() == reflect.Struct:
+			c.Field(i).Set(exportedFields(field))
+
+		case f.Type.Kind() == reflect.Interface && !field.IsNil():
+			if err, ok := field.Interface().(error); ok {
+				c.Field(i).Set(reflect.ValueOf(comparableError(err)))
+			} else {
+				c.Field(i).Set(field)
+			}
+
+		default:
+			c.Field(i).Set(field)
+		}
+	}
+
+	return c
+}
+
+// assertPanicsWithError asserts that fn panics with an error that equals expected, regardless of the original Python
+// exceptions.
+func assertPanicsWithError(t *testing.T, expected error, fn func()) {
+	t.Helper()
+
+	defer func() {
+		t.Helper()
+
+		r := recover()
+
+		err, ok := r.(error)
+		require.True(t, ok, "expected a panic with an error, got %v", r)
+
+		assert.Equal(t, expected, comparableError(err))
+	}()
+
+	fn()
+
+	t.Error("expected a panic")
+}
+
+func TestLastError_NoError(t *testing.T) {
+	withGIL(t)
+
+	require.NoError(t, python3.LastError())
+}
+
+func TestLastError(t *testing.T) {
+	withGIL(t)
+
+	exception := func(typ string) python3.Exception {
+		return python3.Exception{Message: "error", Type: typ}
+	}
+
+	testCases := []struct {
+		scenario string
+		class    *cpy3.PyObject
+		expected error
+	}{
+		{
+			scenario: "Exception",
+			class:    cpy3.PyExc_Exception,
+			expected: exception("Exception"),
+		},
+		{
+			scenario: "ArithmeticError",
+			class:    cpy3.PyExc_ArithmeticError,
+			expected: python3.ArithmeticError{Exception: exception("ArithmeticError")},
+		},
+		{
+			scenario: "OverflowError",
+			class:    cpy3.PyExc_OverflowError,
+			expected: python3.OverflowError{ArithmeticError: python3.ArithmeticError{Exception: exception("OverflowError")}},
+		},
+		{
+			scenario: "ZeroDivisionError",
+			class:    cpy3.PyExc_ZeroDivisionError,
+			expected: python3.ZeroDivisionError{ArithmeticError: python3.ArithmeticError{Exception: exception("ZeroDivisionError")}},
+		},
+		{
+			scenario: "AssertionError",
+			class:    cpy3.PyExc_AssertionError,
+			expected: python3.AssertionError{Exception: exception("AssertionError")},
+		},
+		{
+			scenario: "AttributeError",
+			class:    cpy3.PyExc_AttributeError,
+			expected: python3.AttributeError{Exception: exception("AttributeError")},
+		},
+		{
+			scenario: "LookupError",
+			class:    cpy3.PyExc_LookupError,
+			expected: python3.LookupError{Exception: exception("LookupError")},
+		},
+		{
+			scenario: "IndexError",
+			class:    cpy3.PyExc_IndexError,
+			expected: python3.IndexError{LookupError: python3.LookupError{Exception: exception("IndexError")}},
+		},
+		{
+			scenario: "KeyError",
+			class:    cpy3.PyExc_KeyError,
+			expected: python3.KeyError{LookupError: python3.LookupError{Exception: python3.Exception{Message: "'error'", Type: "KeyError"}}},
+		},
+		{
+			scenario: "MemoryError",
+			class:    cpy3.PyExc_MemoryError,
+			expected: python3.MemoryError{Exception: exception("MemoryError")},
+		},
+		{
+			scenario: "NameError",
+			class:    cpy3.PyExc_NameError,
+			expected: python3.NameError{Exception: exception("NameError")},
+		},
+		{
+			scenario: "OSError",
+			class:    cpy3.PyExc_OSError,
+			expected: python3.OSError{Exception: exception("OSError")},
+		},
+		{
+			scenario: "TimeoutError",
+			class:    cpy3.PyExc_TimeoutError,
+			expected: python3.TimeoutError{OSError: python3.OSError{Exception: exception("TimeoutError")}},
+		},
+		{
+			scenario: "RuntimeError",
+			class:    cpy3.PyExc_RuntimeError,
+			expected: python3.RuntimeError{Exception: exception("RuntimeError")},
+		},
+		{
+			scenario: "NotImplementedError",
+			class:    cpy3.PyExc_NotImplementedError,
+			expected: python3.NotImplementedError{RuntimeError: python3.RuntimeError{Exception: exception("NotImplementedError")}},
+		},
+		{
+			scenario: "RecursionError",
+			class:    cpy3.PyExc_RecursionError,
+			expected: python3.RecursionError{RuntimeError: python3.RuntimeError{Exception: exception("RecursionError")}},
+		},
+		{
+			scenario: "StopIteration",
+			class:    cpy3.PyExc_StopIteration,
+			expected: python3.StopIteration{Exception: exception("StopIteration")},
+		},
+		{
+			scenario: "TypeError",
+			class:    cpy3.PyExc_TypeError,
+			expected: python3.TypeError{Exception: exception("TypeError")},
+		},
+		{
+			scenario: "ValueError",
+			class:    cpy3.PyExc_ValueError,
+			expected: python3.ValueError{Exception: exception("ValueError")},
+		},
+		{
+			scenario: "UnicodeError is a ValueError",
+			class:    cpy3.PyExc_UnicodeError,
+			expected: python3.ValueError{Exception: exception("UnicodeError")},
+		},
+		{
+			scenario: "KeyboardInterrupt",
+			class:    cpy3.PyExc_KeyboardInterrupt,
+			expected: python3.KeyboardInterrupt{BaseException: python3.BaseException{Exception: exception("KeyboardInterrupt")}},
+		},
+		{
+			scenario: "SystemExit",
+			class:    cpy3.PyExc_SystemExit,
+			expected: python3.BaseException{Exception: exception("SystemExit")},
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			cpy3.PyErr_SetString(tc.class, "error")
+
+			actual := python3.LastError()
+
+			assert.Equal(t, tc.expected, comparableError(actual))
+			require.NoError(t, python3.LastError())
+		})
+	}
+}
+
+func TestLastError_OSError(t *testing.T) {
+	withGIL(t)
+
+	_, err := python3.MustImportModule("builtins").TryCallMethod("open", "/not/exists.txt")
+
+	expected := python3.FileNotFoundError{
+		OSError: python3.OSError{
+			Exception: python3.Exception{
+				Message: `[Errno 2] No such file or directory: '/not/exists.txt'`,
+				Type:    "FileNotFoundError",
+			},
+			Errno:    2,
+			Strerror: "No such file or directory",
+			Filename: "/not/exists.txt",
+		},
+	}
+
+	require.Equal(t, expected, comparableError(err))
+
+	var osErr python3.OSError
+
+	require.ErrorAs(t, err, &osErr)
+	assert.Equal(t, expected.OSError, comparableError(osErr))
+}
+
+func TestLastError_As(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		class    *cpy3.PyObject
+		target   any
+		expected bool
+	}{
+		{
+			scenario: "KeyError is a LookupError",
+			class:    cpy3.PyExc_KeyError,
+			target:   &python3.LookupError{},
+			expected: true,
+		},
+		{
+			scenario: "IndexError is a LookupError",
+			class:    cpy3.PyExc_IndexError,
+			target:   &python3.LookupError{},
+			expected: true,
+		},
+		{
+			scenario: "ValueError is not a LookupError",
+			class:    cpy3.PyExc_ValueError,
+			target:   &python3.LookupError{},
+			expected: false,
+		},
+		{
+			scenario: "KeyError is not an IndexError",
+			class:    cpy3.PyExc_KeyError,
+			target:   &python3.IndexError{},
+			expected: false,
+		},
+		{
+			scenario: "ZeroDivisionError is an ArithmeticError",
+			class:    cpy3.PyExc_ZeroDivisionError,
+			target:   &python3.ArithmeticError{},
+			expected: true,
+		},
+		{
+			scenario: "TimeoutError is an OSError",
+			class:    cpy3.PyExc_TimeoutError,
+			target:   &python3.OSError{},
+			expected: true,
+		},
+		{
+			scenario: "ModuleNotFoundError is an ImportError",
+			class:    cpy3.PyExc_ModuleNotFoundError,
+			target:   &python3.ImportError{},
+			expected: true,
+		},
+		{
+			scenario: "RecursionError is a RuntimeError",
+			class:    cpy3.PyExc_RecursionError,
+			target:   &python3.RuntimeError{},
+			expected: true,
+		},
+		{
+			scenario: "KeyError is an Exception",
+			class:    cpy3.PyExc_KeyError,
+			target:   &python3.Exception{},
+			expected: true,
+		},
+		{
+			scenario: "OSError is an Exception",
+			class:    cpy3.PyExc_FileNotFoundError,
+			target:   &python3.Exception{},
+			expected: true,
+		},
+		{
+			scenario: "KeyError is a BaseException",
+			class:    cpy3.PyExc_KeyError,
+			target:   &python3.BaseException{},
+			expected: true,
+		},
+		{
+			scenario: "KeyboardInterrupt is a BaseException",
+			class:    cpy3.PyExc_KeyboardInterrupt,
+			target:   &python3.BaseException{},
+			expected: true,
+		},
+		{
+			scenario: "KeyboardInterrupt is not an Exception",
+			class:    cpy3.PyExc_KeyboardInterrupt,
+			target:   &python3.Exception{},
+			expected: false,
+		},
+		{
+			scenario: "SystemExit is not an Exception",
+			class:    cpy3.PyExc_SystemExit,
+			target:   &python3.Exception{},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			cpy3.PyErr_SetString(tc.class, "error")
+
+			err := python3.LastError()
+
+			assert.Equal(t, tc.expected, errors.As(err, tc.target))
+		})
+	}
+}
+
+func execPython(code string) error {
+	_, err := python3.MustImportModule("builtins").TryCallMethod("exec", code, map[string]any{})
+
+	return err
+}
+
+func TestLastError_Details(t *testing.T) {
+	withGIL(t)
+
+	err := execPython(`
+def lookup(d):
+    return d["missing"]
+
+def run():
+    try:
+        lookup({})
+    except KeyError as e:
+        raise ValueError("invalid config") from e
+
+try:
+    run()
+except ValueError as e:
+    e.add_note("while loading settings")
+    raise
+`)
+
+	var valueErr python3.ValueError
+
+	require.ErrorAs(t, err, &valueErr)
+
+	assert.Equal(t, "invalid config", valueErr.Message)
+	assert.Equal(t, "ValueError", valueErr.Type)
+	assert.Equal(t, []string{"while loading settings"}, valueErr.Notes())
+	assert.Nil(t, valueErr.Context)
+
+	expectedFrames := []python3.Frame{
+		{File: "<string>", Line: 12, Function: "<module>"},
+		{File: "<string>", Line: 9, Function: "run"},
+	}
+
+	assert.Equal(t, expectedFrames, valueErr.Traceback())
+
+	var keyErr python3.KeyError
+
+	require.ErrorAs(t, err, &keyErr)
+
+	assert.Equal(t, "'missing'", keyErr.Message)
+	assert.Equal(t, []python3.Frame{
+		{File: "<string>", Line: 7, Function: "run"},
+		{File: "<string>", Line: 3, Function: "lookup"},
+	}, keyErr.Traceback())
+
+	expected := `Traceback (most recent call last):
+  File "<string>", line 7, in run
+  File "<string>", line 3, in lookup
+KeyError: 'missing'
+
+The above exception was the direct cause of the following exception:
+
+Traceback (most recent call last):
+  File "<string>", line 12, in <module>
+  File "<string>", line 9, in run
+ValueError: invalid config
+while loading settings`
+
+	assert.Equal(t, expected, fmt.Sprintf("%+v", err))
+	assert.Equal(t, "invalid config", fmt.Sprintf("%v", err))
+	assert.Equal(t, `"invalid config"`, fmt.Sprintf("%q", err))
+}
+
+func TestLastError_Context(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario        string
+		code            string
+		expectedContext bool
+	}{
+		{
+			scenario: "implicit context",
+			code: `
+try:
+    1 / 0
+except ZeroDivisionError:
+    raise RuntimeError("failed")
+`,
+			expectedContext: true,
+		},
+		{
+			scenario: "suppressed context",
+			code: `
+try:
+    1 / 0
+except ZeroDivisionError:
+    raise RuntimeError("failed") from None
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			err := execPython(tc.code)
+
+			var runtimeErr python3.RuntimeError
+
+			require.ErrorAs(t, err, &runtimeErr)
+			assert.Nil(t, runtimeErr.Cause)
+			assert.Equal(t, tc.expectedContext, errors.As(err, &python3.ZeroDivisionError{}))
+
+			if tc.expectedContext {
+				assert.Contains(t, fmt.Sprintf("%+v", err), "During handling of the above exception, another exception occurred:")
+			}
+		})
+	}
+}
+
+func TestLastError_QualifiedType(t *testing.T) {
+	withGIL(t)
+
+	_, err := python3.MustImportModule("json").TryCallMethod("loads", "{")
+
+	var exception python3.Exception
+
+	require.ErrorAs(t, err, &exception)
+	assert.Equal(t, "json.decoder.JSONDecodeError", exception.Type)
+	assert.NotEmpty(t, exception.Traceback())
+}
+
+func TestSetError(t *testing.T) {
+	withGIL(t)
+
+	exception := python3.Exception{Message: "error", Type: "ValueError"}
+
+	testCases := []struct {
+		scenario string
+		err      error
+		expected error
+	}{
+		{
+			scenario: "Exception",
+			err:      python3.Exception{Message: "error"},
+			expected: python3.Exception{Message: "error", Type: "Exception"},
+		},
+		{
+			scenario: "ValueError",
+			err:      python3.ValueError{Exception: python3.Exception{Message: "error"}},
+			expected: python3.ValueError{Exception: exception},
+		},
+		{
+			scenario: "ZeroDivisionError",
+			err:      python3.ZeroDivisionError{ArithmeticError: python3.ArithmeticError{Exception: python3.Exception{Message: "error"}}},
+			expected: python3.ZeroDivisionError{ArithmeticError: python3.ArithmeticError{Exception: python3.Exception{Message: "error", Type: "ZeroDivisionError"}}},
+		},
+		{
+			scenario: "KeyboardInterrupt",
+			err:      python3.KeyboardInterrupt{BaseException: python3.BaseException{Exception: python3.Exception{Message: "stop"}}},
+			expected: python3.KeyboardInterrupt{BaseException: python3.BaseException{Exception: python3.Exception{Message: "stop", Type: "KeyboardInterrupt"}}},
+		},
+		{
+			scenario: "wrapped KeyError",
+			err:      fmt.Errorf("lookup: %w", python3.KeyError{LookupError: python3.LookupError{Exception: python3.Exception{Message: "missing"}}}),
+			expected: python3.KeyError{LookupError: python3.LookupError{Exception: python3.Exception{Message: "'missing'", Type: "KeyError"}}},
+		},
+		{
+			scenario: "RuntimeError caused by KeyError",
+			err: python3.RuntimeError{Exception: python3.Exception{
+				Message: "failed",
+				Cause:   python3.KeyError{LookupError: python3.LookupError{Exception: python3.Exception{Message: "missing"}}},
+			}},
+			expected: python3.RuntimeError{Exception: python3.Exception{Message: "failed", Type: "RuntimeError"}},
+		},
+		{
+			scenario: "ModuleNotFoundError",
+			err: python3.ModuleNotFoundError{ImportError: python3.ImportError{
+				Exception: python3.Exception{Message: "No module named 'foo'"},
+				Module:    "foo",
+				Path:      "/tmp/foo.py",
+			}},
+			expected: python3.ModuleNotFoundError{ImportError: python3.ImportError{
+				Exception: python3.Exception{Message: "No module named 'foo'", Type: "ModuleNotFoundError"},
+				Module:    "foo",
+				Path:      "/tmp/foo.py",
+			}},
+		},
+		{
+			scenario: "FileNotFoundError",
+			err: python3.FileNotFoundError{OSError: python3.OSError{
+				Errno:    2,
+				Strerror: "No such file or directory",
+				Filename: "/tmp/foo.txt",
+			}},
+			expected: python3.FileNotFoundError{OSError: python3.OSError{
+				Exception: python3.Exception{Message: "[Errno 2] No such file or directory: '/tmp/foo.txt'", Type: "FileNotFoundError"},
+				Errno:     2,
+				Strerror:  "No such file or directory",
+				Filename:  "/tmp/foo.txt",
+			}},
+		},
+		{
+			scenario: "SyntaxError",
+			err: python3.SyntaxError{
+				Exception: python3.Exception{Message: "invalid syntax"},
+				Filename:  "transform.py",
+				Line:      3,
+				Offset:    7,
+				Text:      "x = = 1",
+			},
+			expected: python3.SyntaxError{
+				Exception: python3.Exception{Message: "invalid syntax", Type: "SyntaxError"},
+				Filename:  "transform.py",
+				Line:      3,
+				Offset:    7,
+				Text:      "x = = 1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			python3.SetError(tc.err)
+
+			actual := python3.LastError()
+
+			assert.Equal(t, tc.expected, comparableError(actual))
+			require.NoError(t, python3.LastError())
+		})
+	}
+}
+
+func TestSetError_Nil(t *testing.T) {
+	withGIL(t)
+
+	python3.SetError(nil)
+
+	require.NoError(t, python3.LastError())
+}
+
+func TestSetError_ReRaise(t *testing.T) {
+	withGIL(t)
+
+	expected := execPython(`raise KeyError("missing")`)
+	require.Error(t, expected)
+
+	python3.SetError(expected)
+
+	actual := python3.LastError()
+
+	assert.Equal(t, fmt.Sprintf("%+v", expected), fmt.Sprintf("%+v", actual))
+
+	var expectedErr, actualErr python3.KeyError
+
+	require.ErrorAs(t, expected, &expectedErr)
+	require.ErrorAs(t, actual, &actualErr)
+
+	assert.Same(t, expectedErr.Object(), actualErr.Object())
+}
+
+func TestSetError_ReRaise_Released(t *testing.T) {
+	withGIL(t)
+
+	err := execPython(`raise ValueError("invalid")`)
+	require.Error(t, err)
+
+	var valueErr python3.ValueError
+
+	require.ErrorAs(t, err, &valueErr)
+
+	valueErr.Release()
+
+	python3.SetError(err)
+
+	actual := python3.LastError()
+
+	expected := python3.ValueError{Exception: python3.Exception{Message: "invalid", Type: "ValueError"}}
+
+	assert.Equal(t, expected, comparableError(actual))
+}
+
+func TestLastError_Object(t *testing.T) {
+	withGIL(t)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	err := python3.Exec(`
+try:
+    raise KeyError("missing")
+except KeyError as e:
+    cause = e
+    err = ValueError("invalid")
+    raise err from e
+`, globals, nil)
+
+	var valueErr python3.ValueError
+
+	require.ErrorAs(t, err, &valueErr)
+
+	assert.Same(t, globals.Get("err"), valueErr.Object())
+	assert.Equal(t, "ValueError", python3.TypeName(valueErr.Object()))
+	assert.Equal(t, 2, refCount(t, globals.Get("err")))
+
+	valueErr.Release()
+
+	assert.Nil(t, valueErr.Object())
+	assert.Equal(t, 1, refCount(t, globals.Get("err")))
+	// The cause is still referenced by the globals, and by __cause__ and __context__ of the exception.
+	assert.Equal(t, 3, refCount(t, globals.Get("cause")))
+
+	assert.NotPanics(t, valueErr.Release)
+}
+
+func TestLastError_Object_Collected(t *testing.T) {
+	withGIL(t)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	func() {
+		err := python3.Exec("err = ValueError('invalid')\nraise err", globals, nil)
+		require.Error(t, err)
+	}()
+
+	for range 100 {
+		runtime.GC()
+
+		// The exceptions of the collected errors are released by the thread that holds the GIL.
+		require.NoError(t, python3.WithGIL(func() error { return nil }))
+
+		if refCount(t, globals.Get("err")) == 1 {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, 1, refCount(t, globals.Get("err")))
+}
+
+func TestSetError_GoError(t *testing.T) {
+	withGIL(t)
+
+	expected := errors.New("go error")
+
+	python3.SetError(expected)
+
+	assert.True(t, cpy3.PyErr_ExceptionMatches(python3.GoErrorType().PyObject()))
+	assert.True(t, cpy3.PyErr_ExceptionMatches(cpy3.PyExc_RuntimeError))
+
+	actual := python3.LastError()
+
+	assert.Same(t, expected, actual)
+	require.NoError(t, python3.LastError())
+}
+
+func TestSetError_WrappedGoError(t *testing.T) {
+	withGIL(t)
+
+	expected := errors.New("go error")
+
+	python3.SetError(fmt.Errorf("wrapped: %w", expected))
+
+	actual := python3.LastError()
+
+	require.ErrorIs(t, actual, expected)
+	require.EqualError(t, actual, "wrapped: go error")
+}
+
+func TestSetDefaultErrorType(t *testing.T) {
+	withGIL(t)
+
+	valueError := python3.NewObject(cpy3.PyExc_ValueError)
+
+	python3.SetDefaultErrorType(valueError)
+	defer python3.SetDefaultErrorType(nil)
+
+	assert.Equal(t, valueError, python3.DefaultErrorType())
+
+	expected := errors.New("go error")
+
+	python3.SetError(expected)
+
+	assert.True(t, cpy3.PyErr_ExceptionMatches(cpy3.PyExc_ValueError))
+
+	actual := python3.LastError()
+
+	assert.Same(t, expected, actual)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	python3 "go.nhat.io/python/v3"
+	_ "go.nhat.io/python/v3/autoinit"
+)
+
+func main() { //nolint: govet
+	// The thread that initialized the interpreter holds the GIL, release it so that the goroutines can run Python code.
+	state := python3.ReleaseGIL()
+	defer python3.RestoreGIL(state)
+
+	results := make([]float64, 4)
+
+	var wg sync.WaitGroup
+
+	for i := range results {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_ = python3.WithGIL(func() error { //nolint: errcheck
+				math := python3.MustImportModule("math")
+
+				pyResult := math.CallMethodArgs("sqrt", i*i)
+				defer pyResult.DecRef()
+
+				results[i] = python3.AsFloat64(pyResult)
+
+				return nil
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	fmt.Println(results)
+
+	// Output:
+	// [0 1 2 3]
+}
@@ -7,6 +7,12 @@ import (
 )
 
 func main() {
+	if err := python3.Initialize(); err != nil {
+		panic(err)
+	}
+
+	defer python3.Finalize()
+
 	sys := python3.MustImportModule("sys")
 	version := sys.GetAttr("version_info")
 
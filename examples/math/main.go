@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	python3 "go.nhat.io/python/v3"
+	_ "go.nhat.io/python/v3/autoinit"
 )
 
 func main() { //nolint: govet
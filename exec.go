@@ -0,0 +1,117 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include <stdlib.h>
+#include "Python.h"
+*/
+import "C"
+
+import (
+	"os"
+	"unsafe"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// Exec executes Python statements. The globals and the locals are the namespaces of the code, the globals are a new
+// dict if nil, and the locals are the globals if nil.
+func Exec(code string, globals, locals *DictObject) error {
+	result, err := run(code, "<string>", C.Py_file_input, globals, locals)
+	if err != nil {
+		return err
+	}
+
+	result.DecRef()
+
+	return nil
+}
+
+// Eval evaluates a Python expression and returns its value. The globals and the locals are the namespaces of the
+// expression, the globals are a new dict if nil, and the locals are the globals if nil.
+func Eval(expr string, globals, locals *DictObject) (*Object, error) {
+	return run(expr, "<string>", C.Py_eval_input, globals, locals)
+}
+
+// RunFile runs a Python script as the __main__ module, even if the file is not in sys.path.
+func RunFile(path string) error {
+	source, err := os.ReadFile(path) //nolint: gosec
+	if err != nil {
+		return err
+	}
+
+	globals := NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("__name__", "__main__")
+	globals.Set("__file__", path)
+
+	result, err := run(string(source), path, C.Py_file_input, globals, nil)
+	if err != nil {
+		return err
+	}
+
+	result.DecRef()
+
+	return nil
+}
+
+// CompileModule creates a module from its source code and adds it to sys.modules, so that it can be imported by the
+// other modules.
+func CompileModule(name, source string) (*Object, error) {
+	code, err := compile(source, "<"+name+">", C.Py_file_input)
+	if err != nil {
+		return nil, err
+	}
+
+	defer code.DecRef()
+
+	module := cpy3.PyImport_ExecCodeModuleEx(name, code.PyObject(), "<"+name+">")
+	if module == nil {
+		return nil, LastError()
+	}
+
+	return NewObject(module), nil
+}
+
+// run compiles the source code and evaluates it in the namespaces.
+func run(source, filename string, start C.int, globals, locals *DictObject) (*Object, error) {
+	code, err := compile(source, filename, start)
+	if err != nil {
+		return nil, err
+	}
+
+	defer code.DecRef()
+
+	if globals == nil {
+		globals = NewDictObject()
+		defer globals.DecRef()
+	}
+
+	if locals == nil {
+		locals = globals
+	}
+
+	result := C.PyEval_EvalCode(toC(code), toC(globals), toC(locals))
+	if result == nil {
+		return nil, LastError()
+	}
+
+	return fromC(result), nil
+}
+
+// compile compiles the source code, the start is Py_file_input for statements or Py_eval_input for an expression.
+func compile(source, filename string, start C.int) (*Object, error) {
+	cSource := C.CString(source)
+	defer C.free(unsafe.Pointer(cSource))
+
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	code := C.Py_CompileStringExFlags(cSource, cFilename, start, nil, -1)
+	if code == nil {
+		return nil, LastError()
+	}
+
+	return fromC(code), nil
+}
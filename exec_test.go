@@ -0,0 +1,215 @@
+package python_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestExec(t *testing.T) {
+	withGIL(t)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("x", 20)
+
+	err := python3.Exec("y = x * 2 + 2", globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, python3.AsInt(globals.Get("y")))
+}
+
+func TestExec_Locals(t *testing.T) {
+	withGIL(t)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	locals := python3.NewDictObject()
+	defer locals.DecRef()
+
+	globals.Set("x", 1)
+
+	err := python3.Exec("y = x + 1", globals, locals)
+	require.NoError(t, err)
+
+	assert.False(t, globals.Has("y"))
+	assert.Equal(t, 2, python3.AsInt(locals.Get("y")))
+}
+
+func TestExec_Error(t *testing.T) {
+	withGIL(t)
+
+	err := python3.Exec("def transform(v):\n    return v[0]\n\ntransform([])\n", nil, nil)
+
+	var indexErr python3.IndexError
+
+	require.ErrorAs(t, err, &indexErr)
+	assert.Equal(t, []python3.Frame{
+		{File: "<string>", Line: 4, Function: "<module>"},
+		{File: "<string>", Line: 2, Function: "transform"},
+	}, indexErr.Traceback())
+}
+
+func TestExec_SyntaxError(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		code     string
+		expected python3.SyntaxError
+	}{
+		{
+			scenario: "syntax error",
+			code:     "x = (1,\ny = 2\n",
+			expected: python3.SyntaxError{
+				Exception: python3.Exception{Message: "'(' was never closed", Type: "SyntaxError"},
+				Filename:  "<string>",
+				Line:      1,
+				Offset:    5,
+				Text:      "x = (1,",
+			},
+		},
+		{
+			scenario: "indentation error",
+			code:     "if True:\nx = 1\n",
+			expected: python3.SyntaxError{
+				Exception: python3.Exception{Message: "expected an indented block after 'if' statement on line 1", Type: "IndentationError"},
+				Filename:  "<string>",
+				Line:      2,
+				Offset:    1,
+				Text:      "x = 1\n",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			err := python3.Exec(tc.code, nil, nil)
+
+			assert.Equal(t, tc.expected, comparableError(err))
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	withGIL(t)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("values", []int{1, 2, 3})
+
+	result, err := python3.Eval("sum(values) * 2", globals, nil)
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, 12, python3.AsInt(result))
+}
+
+func TestEval_Error(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario      string
+		expr          string
+		expectedError string
+	}{
+		{
+			scenario:      "statement",
+			expr:          "x = 1",
+			expectedError: "invalid syntax",
+		},
+		{
+			scenario:      "undefined name",
+			expr:          "unknown + 1",
+			expectedError: "name 'unknown' is not defined",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			result, err := python3.Eval(tc.expr, nil, nil)
+
+			require.EqualError(t, err, tc.expectedError)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	withGIL(t)
+
+	err := python3.RunFile("testdata/script.py")
+	require.NoError(t, err)
+
+	sys := python3.MustImportModule("sys")
+
+	result := sys.GetAttr("script_result")
+	defer result.DecRef()
+
+	expected := map[string]string{"name": "__main__", "file": "script.py"}
+
+	assert.Equal(t, expected, python3.MustUnmarshalAs[map[string]string](result))
+}
+
+func TestRunFile_Error(t *testing.T) {
+	withGIL(t)
+
+	err := python3.RunFile("testdata/not_exists.py")
+
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "broken.py")
+
+	require.NoError(t, os.WriteFile(path, []byte("raise ValueError('broken')\n"), 0o600))
+
+	err = python3.RunFile(path)
+
+	var valueErr python3.ValueError
+
+	require.ErrorAs(t, err, &valueErr)
+	assert.Equal(t, []python3.Frame{{File: path, Line: 1, Function: "<module>"}}, valueErr.Traceback())
+}
+
+func TestCompileModule(t *testing.T) {
+	withGIL(t)
+
+	module, err := python3.CompileModule("transforms", "def double(v):\n    return v * 2\n")
+	require.NoError(t, err)
+
+	defer module.DecRef()
+
+	result, err := module.TryCallMethod("double", 21)
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, 42, python3.AsInt(result))
+
+	imported, err := python3.Eval("__import__('transforms').double(2)", nil, nil)
+	require.NoError(t, err)
+
+	defer imported.DecRef()
+
+	assert.Equal(t, 4, python3.AsInt(imported))
+}
+
+func TestCompileModule_Error(t *testing.T) {
+	withGIL(t)
+
+	module, err := python3.CompileModule("broken", "def broken(:\n")
+
+	var syntaxErr python3.SyntaxError
+
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, "<broken>", syntaxErr.Filename)
+	assert.Nil(t, module)
+}
@@ -9,6 +9,8 @@ import (
 )
 
 func TestFloat64(t *testing.T) {
+	withGIL(t)
+
 	f := python3.NewFloat64(3.14)
 
 	assert.NotNil(t, f)
@@ -0,0 +1,70 @@
+package python
+
+import (
+	"math/big"
+	"reflect"
+)
+
+var ratType = reflect.TypeFor[big.Rat]()
+
+// IsFraction returns true if o is a Python fractions.Fraction object.
+func IsFraction(o PyObjector) bool {
+	return isInstanceOf(o, "fractions", "Fraction")
+}
+
+// NewFraction creates a new Python fractions.Fraction object from r without loss of precision.
+func NewFraction(r *big.Rat) (*Object, error) {
+	fractions, err := ImportModule("fractions")
+	if err != nil {
+		return nil, err
+	}
+
+	num := NewBigInt(r.Num())
+	defer num.DecRef()
+
+	denom := NewBigInt(r.Denom())
+	defer denom.DecRef()
+
+	return fractions.TryCallMethod("Fraction", num, denom)
+}
+
+// AsRat converts a Python fractions.Fraction object to a *big.Rat without loss of precision. It returns nil if o is not
+// a fraction.
+func AsRat(o *Object) *big.Rat {
+	v, err := asRat(o)
+	if err != nil {
+		return nil
+	}
+
+	return v
+}
+
+func asRat(o *Object) (*big.Rat, error) {
+	if !IsFraction(o) {
+		return nil, &UnmarshalTypeError{Value: TypeName(o), Type: ratType}
+	}
+
+	num, err := ratPart(o, "numerator")
+	if err != nil {
+		return nil, err
+	}
+
+	denom, err := ratPart(o, "denominator")
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).SetFrac(num, denom), nil
+}
+
+// ratPart returns the numerator or the denominator of a fraction.
+func ratPart(o *Object, name string) (*big.Int, error) {
+	v, err := o.TryGetAttr(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer v.DecRef()
+
+	return asBigInt(v)
+}
@@ -0,0 +1,129 @@
+package python_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestFraction(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		value          *big.Rat
+		expectedString string
+	}{
+		{
+			scenario:       "zero",
+			value:          new(big.Rat),
+			expectedString: "0",
+		},
+		{
+			scenario:       "negative",
+			value:          big.NewRat(-3, 4),
+			expectedString: "-3/4",
+		},
+		{
+			scenario:       "normalized",
+			value:          big.NewRat(10, 4),
+			expectedString: "5/2",
+		},
+		{
+			scenario:       "wide",
+			value:          new(big.Rat).SetFrac(bigInt(t, "123456789012345678901234567891"), bigInt(t, "7")),
+			expectedString: "123456789012345678901234567891/7",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.NewFraction(tc.value)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			assert.True(t, python3.IsFraction(o))
+			assert.Equal(t, tc.expectedString, o.String())
+			assert.Equal(t, tc.value.RatString(), python3.AsRat(o).RatString())
+		})
+	}
+}
+
+func TestAsRat_NotFraction(t *testing.T) {
+	withGIL(t)
+
+	assert.False(t, python3.IsFraction(python3.NewFloat64(0.5)))
+	assert.Nil(t, python3.AsRat(python3.NewInt(1)))
+}
+
+func TestMarshal_Rat(t *testing.T) {
+	withGIL(t)
+
+	r := big.NewRat(1, 3)
+
+	o, err := python3.Marshal(r)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.True(t, python3.IsFraction(o))
+	assert.Equal(t, "1/3", o.String())
+
+	o, err = python3.Marshal(*r)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.Equal(t, "1/3", o.String())
+}
+
+func TestUnmarshal_Fraction(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`(lambda f: {"rate": f.Fraction(2, 3), "rates": [f.Fraction(-1, 7)]})(__import__("fractions"))`,
+		nil, nil,
+	)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	type record struct {
+		Rate  big.Rat    `python:"rate"`
+		Rates []*big.Rat `python:"rates"`
+	}
+
+	actual, err := python3.UnmarshalAs[record](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewRat(2, 3), &actual.Rate)
+	assert.Equal(t, []*big.Rat{big.NewRat(-1, 7)}, actual.Rates)
+
+	values, err := python3.UnmarshalAs[map[string]any](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"rate": big.NewRat(2, 3), "rates": []any{big.NewRat(-1, 7)}}, values)
+
+	rate := o.GetItem("rate")
+	defer rate.DecRef()
+
+	s, err := python3.UnmarshalAs[string](rate)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2/3", s)
+}
+
+func TestUnmarshal_Fraction_NotFraction(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewFloat64(0.5)
+	defer o.DecRef()
+
+	_, err := python3.UnmarshalAs[big.Rat](o)
+
+	require.EqualError(t, err, "python3: cannot unmarshal float into Go value of type big.Rat")
+}
@@ -0,0 +1,42 @@
+#include <stdlib.h>
+#include <string.h>
+#include "Python.h"
+#include "_cgo_export.h"
+
+uintptr_t handleFromCapsule(PyObject *capsule);
+
+static PyObject *callFunction(PyObject *self, PyObject *args, PyObject *kwargs) {
+	return callGoFunction(handleFromCapsule(self), args, kwargs);
+}
+
+PyObject *newFunction(const char *name, PyObject *capsule) {
+	size_t size = strlen(name) + 1;
+	PyMethodDef *def = malloc(sizeof(PyMethodDef) + size);
+	PyObject *fn;
+
+	if (def == NULL) {
+		return PyErr_NoMemory();
+	}
+
+	memcpy((char *)(def + 1), name, size);
+
+	def->ml_name = (const char *)(def + 1);
+	def->ml_meth = (PyCFunction)(void (*)(void))callFunction;
+	def->ml_flags = METH_VARARGS | METH_KEYWORDS;
+	def->ml_doc = NULL;
+
+	// The capsule frees the definition when the function, which owns the capsule, is destroyed.
+	if (PyCapsule_SetContext(capsule, def) != 0) {
+		free(def);
+
+		return NULL;
+	}
+
+	fn = PyCFunction_NewEx(def, capsule, NULL);
+	if (fn == NULL) {
+		PyCapsule_SetContext(capsule, NULL);
+		free(def);
+	}
+
+	return fn;
+}
@@ -0,0 +1,288 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include <stdlib.h>
+#include "Python.h"
+
+PyObject *newFunction(const char *name, PyObject *capsule);
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/cgo"
+	"slices"
+	"unsafe"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// ErrNotFunction indicates that the value is not a Go function.
+var ErrNotFunction = errors.New("value is not a function")
+
+var (
+	errorType  = reflect.TypeFor[error]()
+	objectType = reflect.TypeFor[*Object]()
+)
+
+// function is a Go function that is called from Python.
+type function struct {
+	name   string
+	fn     reflect.Value
+	kwargs reflect.Type
+}
+
+// NewFunction creates a Python callable that calls the Go function fn.
+//
+// The positional arguments are converted to the parameters of fn using Unmarshal, except the *Object parameters that
+// receive the arguments as is. If the last parameter of fn is a struct, it receives the keyword arguments, which are
+// matched with its fields like dict keys. The structs that are converted from a single Python object, such as
+// time.Time, big.Int or the types that implement Unmarshaler, are positional parameters instead.
+//
+// The results of fn are converted using Marshal: no result is None, one result is the returned value, and several
+// results are a tuple. A returned Python object, such as an *Object or a *List[T], is a reference that fn gives away,
+// so fn must call PyObject().IncRef() on an object that it does not own, such as one of its arguments. If the last
+// result of fn is an error, a non-nil error is raised as a Python exception, see SetError. A panic is raised as a
+// GoError.
+func NewFunction(name string, fn any) (*Object, error) {
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return nil, fmt.Errorf("%w: %T", ErrNotFunction, fn)
+	}
+
+	return newFunction(name, rv)
+}
+
+// MustNewFunction creates a Python callable that calls the Go function fn, and panics if it fails.
+func MustNewFunction(name string, fn any) *Object {
+	o, err := NewFunction(name, fn)
+	if err != nil {
+		panic(err)
+	}
+
+	return o
+}
+
+// newFunction creates a Python callable that calls the Go function rv.
+func newFunction(name string, rv reflect.Value) (*Object, error) {
+	f := &function{name: name, fn: rv}
+
+	if t := rv.Type(); t.NumIn() > 0 && !t.IsVariadic() && isKwargsType(t.In(t.NumIn()-1)) {
+		f.kwargs = t.In(t.NumIn() - 1)
+	}
+
+	capsule := newHandleCapsule(f)
+	if capsule == nil {
+		return nil, LastError()
+	}
+
+	defer capsule.DecRef()
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	o := C.newFunction(cName, toC(capsule))
+	if o == nil {
+		return nil, LastError()
+	}
+
+	return fromC(o), nil
+}
+
+// isKwargsType returns true if the parameter type t receives the keyword arguments.
+func isKwargsType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !isScalarStruct(t)
+}
+
+//export callGoFunction
+func callGoFunction(h C.uintptr_t, args, kwargs *C.PyObject) (result *C.PyObject) {
+	f := cgo.Handle(h).Value().(*function) //nolint: errcheck,forcetypeassert
+
+	defer func() {
+		if r := recover(); r != nil {
+			SetError(fmt.Errorf("%s() panicked: %v", f.name, r)) //nolint: err113
+
+			result = nil
+		}
+	}()
+
+	o, err := f.call((*TupleObject)(fromC(args)), (*DictObject)(fromC(kwargs)))
+	if err != nil {
+		SetError(err)
+
+		return nil
+	}
+
+	return toC(o)
+}
+
+// call calls the Go function with the Python arguments, and returns a new reference to the result.
+func (f *function) call(args *TupleObject, kwargs *DictObject) (*Object, error) {
+	in, err := f.in(args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := f.fn.Call(in)
+
+	if n := len(out); n > 0 && f.fn.Type().Out(n-1) == errorType {
+		if err, _ := out[n-1].Interface().(error); err != nil { //nolint: errcheck
+			return nil, err
+		}
+
+		out = out[:n-1]
+	}
+
+	switch len(out) {
+	case 0:
+		return newNone(), nil
+
+	case 1:
+		return f.result(out[0])
+	}
+
+	tuple := NewTupleObject(len(out))
+
+	for i, v := range out {
+		o, err := f.result(v)
+		if err != nil {
+			tuple.DecRef()
+
+			return nil, err
+		}
+
+		cpy3.PyTuple_SetItem(tuple.PyObject(), i, o.PyObject())
+	}
+
+	return tuple.AsObject(), nil
+}
+
+// result converts a result of the Go function to a new reference. A returned Python object is already a reference owned
+// by the function, so it is handed over as is.
+func (f *function) result(v reflect.Value) (*Object, error) {
+	switch r := v.Interface(); r.(type) {
+	case *cpy3.PyObject, *Object, Objector, PyObjector:
+		if isNil(r) {
+			return newNone(), nil
+		}
+
+		// The Python objects are marshaled as is, and never fail.
+		if o := MustMarshal(r); o.PyObject() != nil {
+			return o, nil
+		}
+
+		return newNone(), nil
+	}
+
+	return marshalNewRef(v.Interface())
+}
+
+// in converts the Python arguments to the parameters of the Go function.
+func (f *function) in(args *TupleObject, kwargs *DictObject) ([]reflect.Value, error) { //nolint: cyclop
+	t := f.fn.Type()
+	numIn := t.NumIn()
+	numArgs := 0
+
+	if args != nil {
+		numArgs = args.Length()
+	}
+
+	numPositional := numIn
+
+	if f.kwargs != nil {
+		numPositional--
+	}
+
+	switch {
+	case t.IsVariadic() && numArgs < numPositional-1:
+		return nil, f.typeError("%s() takes at least %d positional arguments but %d were given", f.name, numPositional-1, numArgs)
+
+	case !t.IsVariadic() && numArgs != numPositional:
+		return nil, f.typeError("%s() takes %d positional arguments but %d were given", f.name, numPositional, numArgs)
+	}
+
+	in := make([]reflect.Value, 0, max(numIn, numArgs))
+
+	for i := range numArgs {
+		pt := t.In(min(i, numIn-1))
+
+		if t.IsVariadic() && i >= numIn-1 {
+			pt = pt.Elem()
+		}
+
+		v, err := f.arg(args.Get(i), pt)
+		if err != nil {
+			return nil, f.typeError("%s() argument %d: %s", f.name, i+1, err.Error())
+		}
+
+		in = append(in, v)
+	}
+
+	if f.kwargs == nil {
+		if kwargs != nil && kwargs.Length() > 0 {
+			return nil, f.typeError("%s() takes no keyword arguments", f.name)
+		}
+
+		return in, nil
+	}
+
+	v, err := f.kwargsArg(kwargs)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(in, v), nil
+}
+
+// arg converts a Python argument to a parameter of the Go function.
+func (f *function) arg(o *Object, t reflect.Type) (reflect.Value, error) {
+	if t == objectType {
+		return reflect.ValueOf(o), nil
+	}
+
+	v := reflect.New(t)
+
+	if err := Unmarshal(o, v.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+
+	return v.Elem(), nil
+}
+
+// kwargsArg converts the keyword arguments to the trailing struct parameter of the Go function.
+func (f *function) kwargsArg(kwargs *DictObject) (reflect.Value, error) {
+	v := reflect.New(f.kwargs).Elem()
+
+	if kwargs == nil {
+		return v, nil
+	}
+
+	fields := cachedTypeFields(f.kwargs)
+
+	var unexpected []string
+
+	kwargs.forEach(func(key, _ *Object) {
+		name := key.String()
+
+		if !slices.ContainsFunc(fields, func(f field) bool { return f.name == name }) {
+			unexpected = append(unexpected, name)
+		}
+	})
+
+	if len(unexpected) > 0 {
+		return reflect.Value{}, f.typeError("%s() got an unexpected keyword argument '%s'", f.name, unexpected[0])
+	}
+
+	if err := Unmarshal(kwargs.AsObject(), v.Addr().Interface()); err != nil {
+		return reflect.Value{}, f.typeError("%s() keyword arguments: %s", f.name, err.Error())
+	}
+
+	return v, nil
+}
+
+func (f *function) typeError(format string, args ...any) error {
+	return TypeError{Exception: NewException(fmt.Sprintf(format, args...))}
+}
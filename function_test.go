@@ -0,0 +1,436 @@
+package python_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+type greetOptions struct {
+	Greeting string `python:"greeting"`
+	Times    int    `python:"times"`
+}
+
+func TestNewFunction(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		fn       any
+		args     []any
+		kwargs   map[string]any
+		expected string
+	}{
+		{
+			scenario: "no result",
+			fn:       func() {},
+			expected: `None`,
+		},
+		{
+			scenario: "one result",
+			fn:       func(a, b int) int { return a + b },
+			args:     []any{1, 2},
+			expected: `3`,
+		},
+		{
+			scenario: "several results",
+			fn:       func(a, b int) (int, int) { return a / b, a % b },
+			args:     []any{7, 2},
+			expected: `(3, 1)`,
+		},
+		{
+			scenario: "result and nil error",
+			fn:       func(s string) (string, error) { return strings.ToUpper(s), nil },
+			args:     []any{"go"},
+			expected: `GO`,
+		},
+		{
+			scenario: "slice argument",
+			fn: func(values []float64) float64 {
+				var sum float64
+
+				for _, v := range values {
+					sum += v
+				}
+
+				return sum
+			},
+			args:     []any{[]any{1.0, 2.5}},
+			expected: `3.5`,
+		},
+		{
+			scenario: "object argument",
+			fn:       func(o *python3.Object) string { return python3.TypeName(o) },
+			args:     []any{map[string]int{}},
+			expected: `dict`,
+		},
+		{
+			scenario: "variadic",
+			fn:       func(sep string, values ...string) string { return strings.Join(values, sep) },
+			args:     []any{"-", "a", "b", "c"},
+			expected: `a-b-c`,
+		},
+		{
+			scenario: "keyword arguments",
+			fn: func(name string, opts greetOptions) string {
+				return strings.Repeat(opts.Greeting+" "+name+"! ", opts.Times)
+			},
+			args:     []any{"gopher"},
+			kwargs:   map[string]any{"greeting": "hello", "times": 2},
+			expected: `hello gopher! hello gopher! `,
+		},
+		{
+			scenario: "time argument",
+			fn:       func(t time.Time) int { return t.Year() },
+			args:     []any{time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)},
+			expected: `2024`,
+		},
+		{
+			scenario: "date argument",
+			fn:       func(d python3.Date) string { return d.String() },
+			args:     []any{python3.Date{Year: 2024, Month: time.March, Day: 5}},
+			expected: `2024-03-05`,
+		},
+		{
+			scenario: "big.Int argument",
+			fn:       func(n big.Int) string { return n.String() },
+			args:     []any{big.NewInt(42)},
+			expected: `42`,
+		},
+		{
+			scenario: "big.Rat argument",
+			fn:       func(r big.Rat) string { return r.RatString() },
+			args:     []any{big.NewRat(1, 3)},
+			expected: `1/3`,
+		},
+		{
+			scenario: "no keyword arguments",
+			fn: func(name string, opts greetOptions) string {
+				return name + ":" + opts.Greeting
+			},
+			args:     []any{"gopher"},
+			expected: `gopher:`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			fn, err := python3.NewFunction("fn", tc.fn)
+			require.NoError(t, err)
+
+			defer fn.DecRef()
+
+			result, err := fn.CallKw(tc.args, tc.kwargs)
+			require.NoError(t, err)
+
+			defer result.DecRef()
+
+			assert.Equal(t, tc.expected, result.String())
+		})
+	}
+}
+
+func TestNewFunction_CallError(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario      string
+		fn            any
+		args          []any
+		kwargs        map[string]any
+		expectedError string
+	}{
+		{
+			scenario:      "too many arguments",
+			fn:            func(int) {},
+			args:          []any{1, 2},
+			expectedError: `fn() takes 1 positional arguments but 2 were given`,
+		},
+		{
+			scenario:      "missing arguments",
+			fn:            func(int, int) {},
+			args:          []any{1},
+			expectedError: `fn() takes 2 positional arguments but 1 were given`,
+		},
+		{
+			scenario:      "missing variadic arguments",
+			fn:            func(string, ...int) {},
+			expectedError: `fn() takes at least 1 positional arguments but 0 were given`,
+		},
+		{
+			scenario:      "wrong argument type",
+			fn:            func(int) {},
+			args:          []any{"one"},
+			expectedError: `fn() argument 1: python3: cannot unmarshal str into Go value of type int`,
+		},
+		{
+			scenario:      "unexpected keyword arguments",
+			fn:            func(int) {},
+			args:          []any{1},
+			kwargs:        map[string]any{"times": 2},
+			expectedError: `fn() takes no keyword arguments`,
+		},
+		{
+			scenario:      "unknown keyword argument",
+			fn:            func(greetOptions) {},
+			kwargs:        map[string]any{"unknown": 2},
+			expectedError: `fn() got an unexpected keyword argument 'unknown'`,
+		},
+		{
+			scenario:      "wrong keyword argument type",
+			fn:            func(greetOptions) {},
+			kwargs:        map[string]any{"times": "twice"},
+			expectedError: `fn() keyword arguments: python3: cannot unmarshal str into Go struct field greetOptions.times of type int`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			fn := python3.MustNewFunction("fn", tc.fn)
+			defer fn.DecRef()
+
+			result, err := fn.CallKw(tc.args, tc.kwargs)
+
+			var typeErr python3.TypeError
+
+			require.ErrorAs(t, err, &typeErr)
+			require.EqualError(t, err, tc.expectedError)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestNewFunction_ReturnError(t *testing.T) {
+	withGIL(t)
+
+	expected := errors.New("go error")
+
+	fn := python3.MustNewFunction("fail", func() (int, error) {
+		return 0, expected
+	})
+	defer fn.DecRef()
+
+	result, err := fn.Call()
+
+	require.ErrorIs(t, err, expected)
+	assert.Nil(t, result)
+}
+
+func TestNewFunction_RaiseInPython(t *testing.T) {
+	withGIL(t)
+
+	lookup := python3.MustNewFunction("lookup", func(key string) (string, error) {
+		return "", python3.KeyError{LookupError: python3.LookupError{Exception: python3.NewException(key)}}
+	})
+	defer lookup.DecRef()
+
+	panics := python3.MustNewFunction("panics", func() {
+		panic("boom")
+	})
+	defer panics.DecRef()
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("lookup", lookup)
+	globals.Set("panics", panics)
+
+	err := python3.Exec(`
+try:
+    lookup("missing")
+except KeyError as e:
+    key_error = e.args[0]
+
+try:
+    panics()
+except RuntimeError as e:
+    panic_error = str(e)
+`, globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "missing", globals.Get("key_error").String())
+	assert.Equal(t, "panics() panicked: boom", globals.Get("panic_error").String())
+}
+
+func TestNewFunction_Callback(t *testing.T) {
+	withGIL(t)
+
+	byLength := python3.MustNewFunction("by_length", func(s string) int {
+		return len(s)
+	})
+	defer byLength.DecRef()
+
+	builtins := python3.MustImportModule("builtins")
+
+	result, err := builtins.CallMethodKw("sorted", []any{[]string{"ccc", "a", "bb"}}, map[string]any{"key": byLength})
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, []string{"a", "bb", "ccc"}, python3.MustUnmarshalAs[[]string](result))
+
+	name := byLength.GetAttr("__name__")
+	defer name.DecRef()
+
+	assert.Equal(t, "by_length", name.String())
+}
+
+func TestNewFunction_ReturnObject(t *testing.T) {
+	withGIL(t)
+
+	item := python3.NewList(0).AsObject()
+	defer item.DecRef()
+
+	before := refCount(t, item)
+
+	fn := python3.MustNewFunction("fn", func() (*python3.Object, *python3.Object) {
+		item.PyObject().IncRef()
+
+		return item, nil
+	})
+	defer fn.DecRef()
+
+	result, err := fn.Call()
+	require.NoError(t, err)
+
+	assert.Equal(t, "([], None)", result.String())
+	assert.Equal(t, before+1, refCount(t, item))
+
+	result.DecRef()
+
+	assert.Equal(t, before, refCount(t, item))
+}
+
+func TestNewFunction_ReturnObject_Argument(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		fn       any
+	}{
+		{
+			scenario: "object",
+			fn: func(o *python3.Object) *python3.Object {
+				o.PyObject().IncRef()
+
+				return o
+			},
+		},
+		{
+			scenario: "list",
+			fn: func(l *python3.AnyList) *python3.AnyList {
+				l.PyObject().IncRef()
+
+				return l
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			list := python3.NewListFromValues[any](1, 2)
+			defer list.DecRef()
+
+			fn := python3.MustNewFunction("fn", tc.fn)
+			defer fn.DecRef()
+
+			before := refCount(t, list)
+
+			result, err := fn.Call(list)
+			require.NoError(t, err)
+
+			assert.Same(t, list.PyObject(), result.PyObject())
+			assert.Equal(t, before+1, refCount(t, list))
+
+			result.DecRef()
+
+			assert.Equal(t, before, refCount(t, list))
+		})
+	}
+}
+
+func TestNewFunction_ReturnObject_New(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		fn       any
+	}{
+		{
+			scenario: "object",
+			fn: func() *python3.Object {
+				return python3.MustMarshal([]int{1, 2})
+			},
+		},
+		{
+			scenario: "list",
+			fn: func() *python3.List[int] {
+				return python3.NewListFromValues(1, 2)
+			},
+		},
+		{
+			scenario: "dict",
+			fn: func() *python3.Dict[string, int] {
+				return python3.NewDictFromMap(map[string]int{"a": 1})
+			},
+		},
+		{
+			scenario: "set",
+			fn: func() *python3.Set[int] {
+				return python3.NewSetFromValues(1, 2)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			fn := python3.MustNewFunction("fn", tc.fn)
+			defer fn.DecRef()
+
+			result, err := fn.Call()
+			require.NoError(t, err)
+
+			defer result.DecRef()
+
+			// The result is only referenced by the caller, so it is not leaked.
+			assert.Equal(t, 1, refCount(t, result))
+		})
+	}
+}
+
+func TestNewFunction_ReturnObject_Nil(t *testing.T) {
+	withGIL(t)
+
+	fn := python3.MustNewFunction("fn", func() (*python3.Object, *python3.AnyList) {
+		return nil, nil
+	})
+	defer fn.DecRef()
+
+	result, err := fn.Call()
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, "(None, None)", result.String())
+}
+
+func TestNewFunction_NotFunction(t *testing.T) {
+	withGIL(t)
+
+	fn, err := python3.NewFunction("fn", 42)
+
+	require.ErrorIs(t, err, python3.ErrNotFunction)
+	require.EqualError(t, err, "value is not a function: int")
+	assert.Nil(t, fn)
+
+	assert.Panics(t, func() {
+		python3.MustNewFunction("fn", (func())(nil))
+	})
+}
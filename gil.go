@@ -0,0 +1,89 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+
+static PyThreadState *detachThread(void) {
+	if (_PyThreadState_UncheckedGet() == NULL) {
+		return NULL;
+	}
+
+	return PyEval_SaveThread();
+}
+*/
+import "C"
+
+import (
+	"runtime"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// ThreadState is the state of a Python thread that released the GIL with ReleaseGIL.
+type ThreadState struct {
+	state *C.PyThreadState
+}
+
+// WithGIL runs fn while holding the GIL. The goroutine is pinned to its OS thread while fn runs, because the Python
+// thread state belongs to the OS thread.
+//
+// The GIL must have been released by the thread that holds it, see ReleaseGIL, otherwise WithGIL blocks until it is.
+// WithGIL can be nested.
+func WithGIL(fn func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	state := cpy3.PyGILState_Ensure()
+	defer cpy3.PyGILState_Release(state)
+
+	releaseCollected()
+
+	return fn()
+}
+
+// ReleaseGIL releases the GIL held by the current thread, so that the other goroutines can run Python code with
+// WithGIL. The goroutine is pinned to its OS thread until RestoreGIL is called. It returns nil if the current thread
+// does not hold the GIL.
+//
+// After the initialization, the GIL is held by the thread that initialized the interpreter. With the autoinit package,
+// it is the thread of the main goroutine when main() starts. So, a program that runs Python code in a pool of
+// goroutines should release the GIL at the beginning of main():
+//
+//	state := python3.ReleaseGIL()
+//	defer python3.RestoreGIL(state)
+func ReleaseGIL() *ThreadState {
+	runtime.LockOSThread()
+
+	state := detachThread()
+	if state == nil {
+		runtime.UnlockOSThread()
+
+		return nil
+	}
+
+	return &ThreadState{state: state}
+}
+
+// RestoreGIL acquires the GIL released by ReleaseGIL and unpins the goroutine from its OS thread.
+func RestoreGIL(s *ThreadState) {
+	if s == nil {
+		return
+	}
+
+	attachThread(s.state)
+	runtime.UnlockOSThread()
+}
+
+// detachThread detaches the thread state of the calling thread, which releases its GIL, and returns it. It returns nil
+// if the calling thread has no attached thread state.
+func detachThread() *C.PyThreadState {
+	return C.detachThread()
+}
+
+// attachThread attaches a thread state returned by detachThread, which acquires its GIL.
+func attachThread(tstate *C.PyThreadState) {
+	if tstate != nil {
+		C.PyEval_RestoreThread(tstate)
+	}
+}
@@ -0,0 +1,113 @@
+package python_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestReleaseGIL(t *testing.T) {
+	withGIL(t)
+
+	state := python3.ReleaseGIL()
+	require.NotNil(t, state)
+
+	done := make(chan error)
+
+	go func() {
+		done <- python3.WithGIL(func() error {
+			_, err := python3.MustImportModule("builtins").TryCallMethod("len", "abc")
+
+			return err
+		})
+	}()
+
+	require.NoError(t, <-done)
+
+	python3.RestoreGIL(state)
+
+	assert.True(t, python3.MustImportModule("builtins").PyObject().HasAttrString("len"))
+}
+
+func TestReleaseGIL_NotHeld(t *testing.T) {
+	assert.Nil(t, python3.ReleaseGIL())
+
+	python3.RestoreGIL(nil)
+}
+
+func TestWithGIL(t *testing.T) {
+	err := python3.WithGIL(func() error {
+		return python3.WithGIL(func() error {
+			math := python3.MustImportModule("math")
+
+			result, err := math.TryCallMethod("sqrt", 16)
+			if err != nil {
+				return err
+			}
+
+			defer result.DecRef()
+
+			assert.InDelta(t, 4.0, python3.AsFloat64(result), 0)
+
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+}
+
+func TestWithGIL_Error(t *testing.T) {
+	expected := errors.New("error")
+
+	err := python3.WithGIL(func() error {
+		return expected
+	})
+
+	require.ErrorIs(t, err, expected)
+}
+
+func TestWithGIL_Goroutines(t *testing.T) {
+	const workers = 8
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]string, workers)
+		errs    = make([]error, workers)
+	)
+
+	for i := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = python3.WithGIL(func() error {
+				list := python3.NewListFromValues(i, i*2)
+				defer list.DecRef()
+
+				results[i] = list.String()
+
+				_, err := python3.MustImportModule("builtins").TryCallMethod("int", "not a number")
+
+				return err
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	// The results are checked without calling Python, because the GIL is released.
+	for i := range workers {
+		assert.Equal(t, fmt.Sprintf("[%d, %d]", i, i*2), results[i])
+
+		var valueErr python3.ValueError
+
+		require.ErrorAs(t, errs[i], &valueErr)
+	}
+}
@@ -0,0 +1,101 @@
+package python
+
+import cpy3 "go.nhat.io/cpy/v3"
+
+// goErrorAttr is the attribute of a Python exception that keeps the original Go error.
+const goErrorAttr = "__go_error__"
+
+// GoErrorType returns the GoError Python exception class of the current interpreter, a subclass of RuntimeError. It is
+// the default type of the exceptions raised by SetError for the errors that are not Python exceptions.
+func GoErrorType() *Object {
+	interp := currentInterpreter()
+
+	if interp.goErrorType == nil {
+		interp.goErrorType = NewObject(cpy3.PyErr_NewException("go.GoError", cpy3.PyExc_RuntimeError, nil))
+
+		interp.registerFinalizer(func() {
+			interp.goErrorType.DecRef()
+
+			interp.goErrorType = nil
+		})
+	}
+
+	return interp.goErrorType
+}
+
+// SetDefaultErrorType sets the Python exception class that SetError raises in the current interpreter for the errors
+// that are not Python exceptions. A nil class restores the default, GoError.
+func SetDefaultErrorType(class *Object) {
+	interp := currentInterpreter()
+
+	if class != nil {
+		class.PyObject().IncRef()
+	}
+
+	interp.defaultErrorType.DecRef()
+
+	interp.defaultErrorType = class
+
+	if class != nil {
+		interp.registerFinalizer(func() {
+			if interp.defaultErrorType == class {
+				interp.defaultErrorType.DecRef()
+
+				interp.defaultErrorType = nil
+			}
+		})
+	}
+}
+
+// DefaultErrorType returns the Python exception class that SetError raises in the current interpreter for the errors
+// that are not Python exceptions.
+func DefaultErrorType() *Object {
+	if t := currentInterpreter().defaultErrorType; t != nil {
+		return t
+	}
+
+	return GoErrorType()
+}
+
+// setGoError raises err as an instance of the default error type that keeps the Go error.
+func setGoError(err error) {
+	class := DefaultErrorType()
+
+	exc, callErr := class.Call(err.Error())
+	if callErr != nil {
+		cpy3.PyErr_SetString(cpy3.PyExc_RuntimeError, err.Error())
+
+		return
+	}
+
+	defer exc.DecRef()
+
+	if capsule := newHandleCapsule(err); capsule != nil {
+		defer capsule.DecRef()
+
+		if setErr := exc.TrySetAttr(goErrorAttr, capsule); setErr != nil {
+			ClearError()
+		}
+	}
+
+	cpy3.PyErr_SetObject(class.PyObject(), exc.PyObject())
+}
+
+// goErrorOf returns the Go error kept by a Python exception raised by SetError.
+func goErrorOf(o *Object) (error, bool) {
+	if !o.PyObject().HasAttrString(goErrorAttr) {
+		return nil, false
+	}
+
+	capsule := o.GetAttr(goErrorAttr)
+	defer capsule.DecRef()
+
+	v, ok := valueFromCapsule(capsule)
+	if !ok {
+		return nil, false
+	}
+
+	err, ok := v.(error)
+
+	return err, ok
+}
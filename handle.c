@@ -0,0 +1,29 @@
+#include <stdlib.h>
+#include "Python.h"
+#include "_cgo_export.h"
+
+static const char *handleCapsuleName = "go.handle";
+
+// deleteHandleCapsule deletes the handle, and frees the context of the capsule if any.
+static void deleteHandleCapsule(PyObject *capsule) {
+	uintptr_t handle = (uintptr_t)PyCapsule_GetPointer(capsule, handleCapsuleName);
+	void *context = PyCapsule_GetContext(capsule);
+
+	if (handle != 0) {
+		deleteHandle(handle);
+	}
+
+	free(context);
+}
+
+PyObject *newHandleCapsule(uintptr_t handle) {
+	return PyCapsule_New((void *)handle, handleCapsuleName, deleteHandleCapsule);
+}
+
+uintptr_t handleFromCapsule(PyObject *capsule) {
+	if (!PyCapsule_IsValid(capsule, handleCapsuleName)) {
+		return 0;
+	}
+
+	return (uintptr_t)PyCapsule_GetPointer(capsule, handleCapsuleName);
+}
@@ -0,0 +1,41 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+
+PyObject *newHandleCapsule(uintptr_t handle);
+uintptr_t handleFromCapsule(PyObject *capsule);
+*/
+import "C"
+
+import "runtime/cgo"
+
+// newHandleCapsule returns a capsule that keeps the value alive until the capsule is destroyed by Python.
+func newHandleCapsule(v any) *Object {
+	h := cgo.NewHandle(v)
+
+	o := C.newHandleCapsule(C.uintptr_t(h))
+	if o == nil {
+		h.Delete()
+
+		return nil
+	}
+
+	return fromC(o)
+}
+
+// valueFromCapsule returns the value kept by a capsule created by newHandleCapsule.
+func valueFromCapsule(o *Object) (any, bool) {
+	h := C.handleFromCapsule(toC(o))
+	if h == 0 {
+		return nil, false
+	}
+
+	return cgo.Handle(h).Value(), true
+}
+
+//export deleteHandle
+func deleteHandle(h C.uintptr_t) {
+	cgo.Handle(h).Delete()
+}
@@ -1,22 +1,22 @@
 package python
 
-import (
-	cpy3 "go.nhat.io/cpy/v3"
-	"go.nhat.io/once"
-)
+import cpy3 "go.nhat.io/cpy/v3"
 
-var modules once.ValuesMap[string, *Object, error]
-
-// ImportModule is a wrapper around the C function PyImport_ImportModule.
+// ImportModule is a wrapper around the C function PyImport_ImportModule. The modules are cached per interpreter.
 func ImportModule(name string) (*Object, error) {
-	module, err := modules.Do(name, func() (*Object, error) {
+	interp := currentInterpreter()
+
+	module, err := interp.modules.Do(name, func() (*Object, error) {
 		module := cpy3.PyImport_ImportModule(name)
 
 		if err := LastError(); err != nil {
 			return nil, err
 		}
 
-		registerFinalizer(func() { module.DecRef() })
+		interp.registerFinalizer(func() {
+			module.DecRef()
+			interp.modules.Delete(name)
+		})
 
 		return NewObject(module), nil
 	})
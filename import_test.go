@@ -11,6 +11,8 @@ import (
 )
 
 func TestMustImportModule(t *testing.T) {
+	withGIL(t)
+
 	module := python3.MustImportModule("sys")
 
 	attr := module.GetAttr("platform")
@@ -23,22 +25,27 @@ func TestMustImportModule(t *testing.T) {
 }
 
 func TestMustImportModule2_NotExists(t *testing.T) {
+	withGIL(t)
+
 	err := python3.ModuleNotFoundError{
 		ImportError: python3.ImportError{
 			Exception: python3.Exception{
 				Message: `No module named 'not_exists'`,
+				Type:    "ModuleNotFoundError",
 			},
 			Module: "not_exists",
 			Path:   "None",
 		},
 	}
 
-	assert.PanicsWithValue(t, err, func() {
+	assertPanicsWithError(t, err, func() {
 		python3.MustImportModule("not_exists")
 	})
 }
 
 func TestImportModule_NotExists(t *testing.T) {
+	withGIL(t)
+
 	module, actual := python3.ImportModule("not_exists")
 
 	require.Nil(t, module)
@@ -47,12 +54,13 @@ func TestImportModule_NotExists(t *testing.T) {
 		ImportError: python3.ImportError{
 			Exception: python3.Exception{
 				Message: `No module named 'not_exists'`,
+				Type:    "ModuleNotFoundError",
 			},
 			Module: "not_exists",
 			Path:   "None",
 		},
 	}
 
-	require.Equal(t, expected, actual)
+	require.Equal(t, expected, comparableError(actual))
 	require.EqualError(t, actual, `No module named 'not_exists'`)
 }
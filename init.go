@@ -1,29 +1,239 @@
 package python
 
-import cpy3 "go.nhat.io/cpy/v3"
+/*
+#cgo pkg-config: python-3.12-embed
+#include <stdlib.h>
+#include "Python.h"
+
+typedef struct {
+	const char *program_name;
+	const char *home;
+	const char *python_path;
+	int isolated;
+	int utf8_mode;
+	int install_signal_handlers;
+} initConfig;
+
+// initialize initializes the interpreter with the config and returns the error message, or NULL on success.
+static const char *initialize(initConfig *c) {
+	PyStatus status;
+	PyPreConfig preconfig;
+	PyConfig config;
+
+	if (c->isolated) {
+		PyPreConfig_InitIsolatedConfig(&preconfig);
+	} else {
+		PyPreConfig_InitPythonConfig(&preconfig);
+	}
+
+	if (c->utf8_mode >= 0) {
+		preconfig.utf8_mode = c->utf8_mode;
+	}
+
+	status = Py_PreInitialize(&preconfig);
+	if (PyStatus_Exception(status)) {
+		return status.err_msg != NULL ? status.err_msg : "cannot pre-initialize the interpreter";
+	}
+
+	if (c->isolated) {
+		PyConfig_InitIsolatedConfig(&config);
+	} else {
+		PyConfig_InitPythonConfig(&config);
+	}
+
+	config.install_signal_handlers = c->install_signal_handlers;
+
+	if (c->program_name != NULL) {
+		status = PyConfig_SetBytesString(&config, &config.program_name, c->program_name);
+		if (PyStatus_Exception(status)) {
+			goto done;
+		}
+	}
+
+	if (c->home != NULL) {
+		status = PyConfig_SetBytesString(&config, &config.home, c->home);
+		if (PyStatus_Exception(status)) {
+			goto done;
+		}
+	}
+
+	if (c->python_path != NULL) {
+		status = PyConfig_SetBytesString(&config, &config.pythonpath_env, c->python_path);
+		if (PyStatus_Exception(status)) {
+			goto done;
+		}
+	}
+
+	status = Py_InitializeFromConfig(&config);
+
+done:
+	PyConfig_Clear(&config);
+
+	if (PyStatus_Exception(status)) {
+		return status.err_msg != NULL ? status.err_msg : "cannot initialize the interpreter";
+	}
+
+	return NULL;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+	"unsafe"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
 
 // ErrPythonInterpreterNotInitialized is the error message when the python interpreter is not initialized.
 var ErrPythonInterpreterNotInitialized = "cannot initialize the python interpreter"
 
-var finializers = make([]func(), 0)
+// ErrPythonInterpreterInitialized indicates that the python interpreter is already initialized.
+var ErrPythonInterpreterInitialized = errors.New("python interpreter is already initialized")
+
+// Option configures the python interpreter.
+type Option func(c *config)
+
+type config struct {
+	programName           string
+	home                  string
+	paths                 []string
+	isolated              bool
+	utf8Mode              int
+	installSignalHandlers bool
+}
+
+// WithProgramName sets the program name, which is used to compute sys.executable and the paths of the standard
+// library.
+func WithProgramName(name string) Option {
+	return func(c *config) {
+		c.programName = name
+	}
+}
+
+// WithPath adds entries to sys.path, before the standard library, like PYTHONPATH.
+func WithPath(paths ...string) Option {
+	return func(c *config) {
+		c.paths = append(c.paths, paths...)
+	}
+}
+
+// WithHome sets the Python home, like PYTHONHOME.
+func WithHome(home string) Option {
+	return func(c *config) {
+		c.home = home
+	}
+}
+
+// WithIsolated runs the interpreter in isolated mode: the environment variables, the user site directory and the
+// current directory are ignored.
+func WithIsolated() Option {
+	return func(c *config) {
+		c.isolated = true
+	}
+}
 
-func init() { // nolint: gochecknoinits
-	cpy3.Py_Initialize()
+// WithUTF8Mode enables or disables the Python UTF-8 mode.
+func WithUTF8Mode(enabled bool) Option {
+	return func(c *config) {
+		c.utf8Mode = 0
 
-	if !cpy3.Py_IsInitialized() {
-		panic(ErrPythonInterpreterNotInitialized)
+		if enabled {
+			c.utf8Mode = 1
+		}
 	}
 }
 
-// Finalize finializes the python interpreter.
+// WithoutSignalHandlers does not install the Python signal handlers, so that the signals are handled by Go.
+func WithoutSignalHandlers() Option {
+	return func(c *config) {
+		c.installSignalHandlers = false
+	}
+}
+
+// Initialize initializes the python interpreter. The calling thread holds the GIL after the initialization, so the
+// calling goroutine is pinned to it until Finalize is called, see ReleaseGIL to run Python code from other goroutines.
+//
+// Import go.nhat.io/python/v3/autoinit to initialize the interpreter with the default options when the program starts.
+func Initialize(opts ...Option) error {
+	if IsInitialized() {
+		return ErrPythonInterpreterInitialized
+	}
+
+	c := config{
+		utf8Mode:              -1,
+		installSignalHandlers: true,
+	}
+
+	for _, o := range opts {
+		o(&c)
+	}
+
+	cfg := C.initConfig{
+		isolated:                boolToCInt(c.isolated),
+		utf8_mode:               C.int(c.utf8Mode),
+		install_signal_handlers: boolToCInt(c.installSignalHandlers),
+	}
+
+	if c.programName != "" {
+		cfg.program_name = C.CString(c.programName)
+		defer C.free(unsafe.Pointer(cfg.program_name))
+	}
+
+	if c.home != "" {
+		cfg.home = C.CString(c.home)
+		defer C.free(unsafe.Pointer(cfg.home))
+	}
+
+	if len(c.paths) > 0 {
+		cfg.python_path = C.CString(strings.Join(c.paths, string(os.PathListSeparator)))
+		defer C.free(unsafe.Pointer(cfg.python_path))
+	}
+
+	// The thread state of the main interpreter belongs to the calling thread.
+	runtime.LockOSThread()
+
+	if msg := C.initialize(&cfg); msg != nil {
+		runtime.UnlockOSThread()
+
+		return errors.New(ErrPythonInterpreterNotInitialized + ": " + C.GoString(msg)) //nolint: err113
+	}
+
+	return nil
+}
+
+// IsInitialized returns true if the python interpreter is initialized.
+func IsInitialized() bool {
+	return cpy3.Py_IsInitialized()
+}
+
+// Finalize finializes the python interpreter, after closing the sub-interpreters, and unpins the goroutine that called
+// Initialize. It does nothing if the interpreter is not initialized.
 func Finalize() {
-	for _, f := range finializers {
-		f()
+	if !IsInitialized() {
+		return
 	}
 
+	closeInterpreters()
+	releaseCollected()
+	mainInterpreter.finalize()
+
 	cpy3.Py_Finalize()
+	runtime.UnlockOSThread()
 }
 
+// registerFinalizer registers a function that is called when the current interpreter is finalized.
 func registerFinalizer(f func()) {
-	finializers = append(finializers, f)
+	currentInterpreter().registerFinalizer(f)
+}
+
+func boolToCInt(b bool) C.int {
+	if b {
+		return 1
+	}
+
+	return 0
 }
@@ -2,14 +2,117 @@ package python_test
 
 import (
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cpy3 "go.nhat.io/cpy/v3"
+
 	python3 "go.nhat.io/python/v3"
 )
 
 // TestMain is the entry point for the test suite.
 func TestMain(m *testing.M) {
-	defer python3.Finalize()
+	path, err := filepath.Abs("testdata")
+	if err != nil {
+		panic(err)
+	}
+
+	// Registered before the initialization to be imported as a built-in module.
+	err = python3.NewModule("gotest").
+		Func("add", func(a, b int) int { return a + b }).
+		Const("VERSION", "1.2").
+		Register()
+	if err != nil {
+		panic(err)
+	}
+
+	err = python3.Initialize(
+		python3.WithProgramName("go-python-test"),
+		python3.WithPath(path),
+		python3.WithUTF8Mode(true),
+		python3.WithoutSignalHandlers(),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// The tests run on the goroutines of the testing package, so the initializing thread releases the GIL for them, see
+	// withGIL.
+	state := python3.ReleaseGIL()
+
+	code := m.Run()
+
+	python3.RestoreGIL(state)
+	python3.Finalize()
+
+	os.Exit(code)
+}
+
+// withGIL pins the goroutine of the test to its OS thread and acquires the GIL until the test and its cleanups finish.
+// It must be called before the other helpers register their cleanups.
+func withGIL(t *testing.T) {
+	t.Helper()
+
+	runtime.LockOSThread()
+
+	state := cpy3.PyGILState_Ensure()
+
+	t.Cleanup(func() {
+		cpy3.PyGILState_Release(state)
+		runtime.UnlockOSThread()
+	})
+}
+
+// run runs fn as a subtest that holds the GIL. The GIL of the parent test is released while the subtest runs.
+func run(t *testing.T, name string, fn func(t *testing.T)) {
+	t.Helper()
+
+	state := python3.ReleaseGIL()
+	defer python3.RestoreGIL(state)
+
+	t.Run(name, func(t *testing.T) {
+		withGIL(t)
+
+		fn(t)
+	})
+}
+
+func TestInitialize_AlreadyInitialized(t *testing.T) {
+	withGIL(t)
+
+	assert.True(t, python3.IsInitialized())
+
+	err := python3.Initialize()
+
+	require.ErrorIs(t, err, python3.ErrPythonInterpreterInitialized)
+}
+
+func TestInitialize_Options(t *testing.T) {
+	withGIL(t)
+
+	path, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	sys := python3.MustImportModule("sys")
+
+	sysPath := sys.GetAttr("path")
+	defer sysPath.DecRef()
+
+	assert.Contains(t, python3.MustUnmarshalAs[[]string](sysPath), path)
+
+	flags := sys.GetAttr("flags")
+	defer flags.DecRef()
+
+	utf8Mode := flags.GetAttr("utf8_mode")
+	defer utf8Mode.DecRef()
+
+	assert.Equal(t, 1, python3.AsInt(utf8Mode))
+
+	message := python3.MustImportModule("greeting").GetAttr("message")
+	defer message.DecRef()
 
-	os.Exit(m.Run()) // nolint: gocritic
+	assert.Equal(t, "hello", message.String())
 }
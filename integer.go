@@ -1,6 +1,17 @@
 package python
 
-import cpy3 "go.nhat.io/cpy/v3"
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
 
 // IsInt returns whether the given object is a Python int object.
 func IsInt(o PyObjector) bool {
@@ -82,3 +93,35 @@ func AsUint64(o *Object) uint64 {
 func asUint64(o *cpy3.PyObject) uint64 {
 	return cpy3.PyLong_AsUnsignedLongLong(o)
 }
+
+// asInt64AndOverflow converts a Python int object to an int64, and returns false if it does not fit.
+func asInt64AndOverflow(o *Object) (int64, bool) {
+	var overflow C.int
+
+	v := C.PyLong_AsLongLongAndOverflow(toC(o), &overflow)
+
+	return int64(v), overflow == 0
+}
+
+// asUint64AndOverflow converts a Python int object to an uint64, and returns false if it is negative or does not fit.
+func asUint64AndOverflow(o *Object) (uint64, bool) {
+	if C._PyLong_Sign(toC(o)) < 0 {
+		return 0, false
+	}
+
+	v := C.PyLong_AsUnsignedLongLong(toC(o))
+	if C.PyErr_Occurred() != nil {
+		C.PyErr_Clear()
+
+		return 0, false
+	}
+
+	return uint64(v), true
+}
+
+// newOverflowError returns an OverflowError for a Python number that does not fit in a Go value of type t.
+func newOverflowError(o *Object, t reflect.Type) error {
+	msg := fmt.Sprintf("python %s %s does not fit in Go value of type %s", TypeName(o), o.String(), t)
+
+	return OverflowError{ArithmeticError: ArithmeticError{Exception: NewException(msg)}}
+}
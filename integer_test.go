@@ -9,6 +9,8 @@ import (
 )
 
 func TestInt(t *testing.T) {
+	withGIL(t)
+
 	i := python3.NewInt(42)
 
 	assert.NotNil(t, i)
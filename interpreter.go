@@ -0,0 +1,200 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+
+// newInterpreter creates a sub-interpreter with its own GIL, and returns its detached thread state, or the error
+// message on failure. The thread state of the calling thread, if any, is restored.
+static const char *newInterpreter(PyThreadState **tstate) {
+	PyInterpreterConfig config = _PyInterpreterConfig_INIT;
+	PyThreadState *saved = _PyThreadState_UncheckedGet();
+	PyStatus status;
+
+	if (saved != NULL) {
+		PyEval_SaveThread();
+	}
+
+	status = Py_NewInterpreterFromConfig(tstate, &config);
+	if (PyStatus_Exception(status)) {
+		if (saved != NULL) {
+			PyEval_RestoreThread(saved);
+		}
+
+		return status.err_msg != NULL ? status.err_msg : "cannot create the interpreter";
+	}
+
+	PyEval_SaveThread();
+
+	if (saved != NULL) {
+		PyEval_RestoreThread(saved);
+	}
+
+	return NULL;
+}
+
+// enterInterpreter attaches a new thread state of the interpreter to the calling thread.
+static PyThreadState *enterInterpreter(PyInterpreterState *interp) {
+	PyThreadState *tstate = PyThreadState_New(interp);
+
+	PyEval_RestoreThread(tstate);
+
+	return tstate;
+}
+
+// leaveInterpreter deletes the thread state attached by enterInterpreter.
+static void leaveInterpreter(PyThreadState *tstate) {
+	PyThreadState_Clear(tstate);
+	PyThreadState_DeleteCurrent();
+}
+
+// currentInterpreter returns the interpreter of the thread state of the calling thread, or NULL.
+static PyInterpreterState *currentInterpreter(void) {
+	PyThreadState *tstate = _PyThreadState_UncheckedGet();
+
+	if (tstate == NULL) {
+		return NULL;
+	}
+
+	return PyThreadState_GetInterpreter(tstate);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+
+	"go.nhat.io/once"
+)
+
+// ErrInterpreterClosed indicates that the interpreter is closed.
+var ErrInterpreterClosed = errors.New("python interpreter is closed")
+
+var (
+	mainInterpreter = &Interpreter{}
+	interpreters    sync.Map // map[*C.PyInterpreterState]*Interpreter
+)
+
+// Interpreter is a Python interpreter. Besides the main interpreter, which is initialized by Initialize, there can be
+// isolated sub-interpreters created by NewInterpreter, each with its own GIL, modules and objects.
+//
+// The objects of an interpreter must not be used in another one.
+type Interpreter struct {
+	tstate *C.PyThreadState
+	interp *C.PyInterpreterState
+
+	modules    once.ValuesMap[string, *Object, error]
+	classes    sync.Map // map[reflect.Type]*Object
+	finalizers []func()
+
+	goErrorType      *Object
+	defaultErrorType *Object
+}
+
+// NewInterpreter creates an isolated sub-interpreter with its own GIL, so that it runs Python code in parallel with the
+// other interpreters. Use Run to run Python code in the interpreter, and Close to destroy it.
+func NewInterpreter() (*Interpreter, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var tstate *C.PyThreadState
+
+	if msg := C.newInterpreter(&tstate); msg != nil {
+		return nil, errors.New("cannot create the python interpreter: " + C.GoString(msg)) //nolint: err113
+	}
+
+	i := &Interpreter{
+		tstate: tstate,
+		interp: C.PyThreadState_GetInterpreter(tstate),
+	}
+
+	interpreters.Store(i.interp, i)
+
+	return i, nil
+}
+
+// Run runs fn in the interpreter. The goroutine is pinned to its OS thread and holds the GIL of the interpreter while
+// fn runs. Run can be called from several goroutines at the same time.
+func (i *Interpreter) Run(fn func() error) error {
+	if i.interp == nil {
+		return ErrInterpreterClosed
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	saved := detachThread()
+	defer attachThread(saved)
+
+	tstate := C.enterInterpreter(i.interp)
+	defer C.leaveInterpreter(tstate)
+
+	releaseCollected()
+
+	return fn()
+}
+
+// Close runs the finalizers of the interpreter and destroys it. It must not be called while Run is running.
+func (i *Interpreter) Close() error {
+	if i.interp == nil {
+		return ErrInterpreterClosed
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	saved := detachThread()
+	defer attachThread(saved)
+
+	attachThread(i.tstate)
+
+	releaseCollected()
+	i.finalize()
+
+	C.Py_EndInterpreter(i.tstate)
+
+	interpreters.Delete(i.interp)
+
+	i.tstate = nil
+	i.interp = nil
+
+	return nil
+}
+
+// finalize runs the finalizers of the interpreter.
+func (i *Interpreter) finalize() {
+	for _, f := range i.finalizers {
+		f()
+	}
+
+	i.finalizers = i.finalizers[:0]
+}
+
+func (i *Interpreter) registerFinalizer(f func()) {
+	i.finalizers = append(i.finalizers, f)
+}
+
+// currentInterpreter returns the interpreter of the calling thread. It is the main interpreter outside of Run.
+func currentInterpreter() *Interpreter {
+	interp := C.currentInterpreter()
+	if interp == nil {
+		return mainInterpreter
+	}
+
+	if i, ok := interpreters.Load(interp); ok {
+		return i.(*Interpreter) //nolint: errcheck,forcetypeassert
+	}
+
+	return mainInterpreter
+}
+
+// closeInterpreters closes all the sub-interpreters.
+func closeInterpreters() {
+	interpreters.Range(func(_, v any) bool {
+		_ = v.(*Interpreter).Close() //nolint: errcheck,forcetypeassert
+
+		return true
+	})
+}
@@ -0,0 +1,145 @@
+package python_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func newInterpreter(t *testing.T) *python3.Interpreter {
+	t.Helper()
+
+	interp, err := python3.NewInterpreter()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = interp.Close() //nolint: errcheck
+	})
+
+	return interp
+}
+
+func TestInterpreter_Run(t *testing.T) {
+	withGIL(t)
+
+	interp := newInterpreter(t)
+
+	err := interp.Run(func() error {
+		sys := python3.MustImportModule("sys")
+
+		return sys.TrySetAttr("tenant", "acme")
+	})
+	require.NoError(t, err)
+
+	err = interp.Run(func() error {
+		tenant, err := python3.MustImportModule("sys").TryGetAttr("tenant")
+		if err != nil {
+			return err
+		}
+
+		defer tenant.DecRef()
+
+		assert.Equal(t, "acme", tenant.String())
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, python3.MustImportModule("sys").PyObject().HasAttrString("tenant"))
+}
+
+func TestInterpreter_Run_Error(t *testing.T) {
+	withGIL(t)
+
+	interp := newInterpreter(t)
+
+	err := interp.Run(func() error {
+		_, err := python3.MustImportModule("builtins").TryCallMethod("int", "not a number")
+
+		return err
+	})
+
+	var valueErr python3.ValueError
+
+	require.ErrorAs(t, err, &valueErr)
+	assert.Equal(t, "invalid literal for int() with base 10: 'not a number'", valueErr.Message)
+}
+
+func TestInterpreter_Run_GoError(t *testing.T) {
+	withGIL(t)
+
+	interp := newInterpreter(t)
+
+	expected := errors.New("go error")
+
+	err := interp.Run(func() error {
+		python3.SetError(expected)
+
+		return python3.LastError()
+	})
+
+	assert.Same(t, expected, err)
+}
+
+func TestInterpreter_Closed(t *testing.T) {
+	withGIL(t)
+
+	interp, err := python3.NewInterpreter()
+	require.NoError(t, err)
+
+	require.NoError(t, interp.Close())
+
+	require.ErrorIs(t, interp.Close(), python3.ErrInterpreterClosed)
+	require.ErrorIs(t, interp.Run(func() error { return nil }), python3.ErrInterpreterClosed)
+}
+
+func TestInterpreter_Parallel(t *testing.T) {
+	withGIL(t)
+
+	const workers = 4
+
+	interps := make([]*python3.Interpreter, workers)
+
+	for i := range interps {
+		interps[i] = newInterpreter(t)
+	}
+
+	var wg sync.WaitGroup
+
+	results := make([]int, workers)
+	errs := make([]error, workers)
+
+	for i, interp := range interps {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = interp.Run(func() error {
+				globals := python3.NewDictObject()
+				defer globals.DecRef()
+
+				_, err := python3.MustImportModule("builtins").TryCallMethod("exec", "result = sum(range(100000))", globals)
+				if err != nil {
+					return err
+				}
+
+				results[i] = python3.AsInt(globals.Get("result"))
+
+				return nil
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	for i := range workers {
+		require.NoError(t, errs[i])
+		assert.Equal(t, 4999950000, results[i])
+	}
+}
@@ -0,0 +1,212 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+*/
+import "C"
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// Iter returns a sequence of the items of an iterable, such as a list, a dict or a generator. The items are pulled from
+// the Python iterator one at a time, so the iterable does not need to fit in memory.
+//
+// Each item is released when the loop body returns, call PyObject().IncRef() to keep it. If the object is not iterable
+// or the iteration raises an exception, the sequence yields the error as the last pair.
+func (o *Object) Iter() iter.Seq2[*Object, error] {
+	return func(yield func(*Object, error) bool) {
+		it := (*cpy3.PyObject)(o).GetIter()
+		if it == nil {
+			yield(nil, LastError())
+
+			return
+		}
+
+		defer it.DecRef()
+
+		for {
+			item := fromC(C.PyIter_Next(toC(NewObject(it))))
+			if item == nil {
+				if err := LastError(); err != nil {
+					yield(nil, err)
+				}
+
+				return
+			}
+
+			ok := yield(item, nil)
+
+			item.DecRef()
+
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// Iterate returns a sequence of the items of an iterable converted to T using Unmarshal. The iteration stops at the
+// first error, which is yielded as the last pair.
+func Iterate[T any](o *Object, opts ...UnmarshalOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range o.Iter() {
+			if err != nil {
+				yield(*new(T), err)
+
+				return
+			}
+
+			v, err := UnmarshalAs[T](item, opts...)
+			if err != nil {
+				yield(v, err)
+
+				return
+			}
+
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// iterator pulls the values of a Go sequence for a Python iterator.
+type iterator[T any] struct {
+	next     func() (T, bool)
+	sentinel *Object
+}
+
+// NewIterator returns a Python iterator that yields the values of the Go sequence seq converted using Marshal. The
+// values are pulled from seq one at a time as Python iterates. Since seq runs in another goroutine while the caller
+// holds the GIL, it must not call Python.
+func NewIterator[T any](seq iter.Seq[T]) (*Object, error) {
+	builtins, err := ImportModule("builtins")
+	if err != nil {
+		return nil, err
+	}
+
+	sentinel, err := builtins.TryCallMethod("object")
+	if err != nil {
+		return nil, err
+	}
+
+	defer sentinel.DecRef()
+
+	next, stop := pull(seq)
+	it := &iterator[T]{next: next, sentinel: sentinel}
+
+	// Stops the sequence if Python drops the iterator before it is exhausted.
+	runtime.AddCleanup(it, func(stop func()) { stop() }, stop)
+
+	fn, err := NewFunction("next", it.pull)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fn.DecRef()
+
+	// iter(fn, sentinel) calls fn until it returns the sentinel.
+	return builtins.TryCallMethod("iter", fn, sentinel)
+}
+
+// MustNewIterator returns a Python iterator that yields the values of the Go sequence seq, and panics if it fails.
+func MustNewIterator[T any](seq iter.Seq[T]) *Object {
+	o, err := NewIterator(seq)
+	if err != nil {
+		panic(err)
+	}
+
+	return o
+}
+
+// pull returns a new reference to the next value of the sequence, or to the sentinel when the sequence is exhausted.
+func (it *iterator[T]) pull() (*Object, error) {
+	v, ok := it.next()
+	if !ok {
+		it.sentinel.PyObject().IncRef()
+
+		return it.sentinel, nil
+	}
+
+	return marshalNewRef(v)
+}
+
+// pull converts the push sequence seq to a pull iterator like iter.Pull does, which cannot be used here because the
+// coroutines of iter.Pull must be resumed from the same locked OS thread, and the Python callbacks run on whatever
+// thread holds the GIL. The sequence runs in its own goroutine that hands over one value per call of next.
+func pull[T any](seq iter.Seq[T]) (func() (T, bool), func()) {
+	var (
+		requests = make(chan struct{})
+		values   = make(chan T)
+		done     = make(chan struct{})
+		started  bool
+		stopOnce sync.Once
+		panicked any
+	)
+
+	run := func() {
+		defer close(done)
+
+		defer func() {
+			panicked = recover()
+		}()
+
+		if _, ok := <-requests; !ok {
+			return
+		}
+
+		seq(func(v T) bool {
+			values <- v
+
+			_, ok := <-requests
+
+			return ok
+		})
+	}
+
+	finished := func() (T, bool) {
+		if panicked != nil {
+			p := panicked
+			panicked = nil
+
+			panic(p)
+		}
+
+		return *new(T), false
+	}
+
+	next := func() (T, bool) {
+		if !started {
+			started = true
+
+			go run()
+		}
+
+		select {
+		case requests <- struct{}{}:
+		case <-done:
+			return finished()
+		}
+
+		select {
+		case v := <-values:
+			return v, true
+		case <-done:
+			return finished()
+		}
+	}
+
+	stop := func() {
+		stopOnce.Do(func() {
+			close(requests)
+		})
+	}
+
+	return next, stop
+}
@@ -0,0 +1,267 @@
+package python_test
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestObject_Iter(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		expr     string
+		expected []string
+	}{
+		{
+			scenario: "list",
+			expr:     `[1, "a", None]`,
+			expected: []string{"1", "a", "None"},
+		},
+		{
+			scenario: "dict",
+			expr:     `{"a": 1, "b": 2}`,
+			expected: []string{"a", "b"},
+		},
+		{
+			scenario: "generator",
+			expr:     `(x * x for x in range(4))`,
+			expected: []string{"0", "1", "4", "9"},
+		},
+		{
+			scenario: "empty",
+			expr:     `()`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Eval(tc.expr, nil, nil)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			var actual []string
+
+			for item, err := range o.Iter() {
+				require.NoError(t, err)
+
+				actual = append(actual, item.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestObject_Iter_Break(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`(x for x in range(100))`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	var actual []string
+
+	for item := range o.Iter() {
+		actual = append(actual, item.String())
+
+		if len(actual) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"0", "1"}, actual)
+
+	// The generator resumes where the loop stopped.
+	next, err := python3.Eval(`next`, nil, nil)
+	require.NoError(t, err)
+
+	defer next.DecRef()
+
+	item, err := next.Call(o)
+	require.NoError(t, err)
+
+	defer item.DecRef()
+
+	assert.Equal(t, "2", item.String())
+}
+
+func TestObject_Iter_Error(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario      string
+		expr          string
+		expectedItems []string
+		expectedError string
+	}{
+		{
+			scenario:      "not iterable",
+			expr:          `42`,
+			expectedError: "'int' object is not iterable",
+		},
+		{
+			scenario:      "error while iterating",
+			expr:          `(1 // x for x in (1, 0))`,
+			expectedItems: []string{"1"},
+			expectedError: "integer division or modulo by zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Eval(tc.expr, nil, nil)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			var (
+				actual  []string
+				iterErr error
+			)
+
+			for item, err := range o.Iter() {
+				if err != nil {
+					iterErr = err
+
+					continue
+				}
+
+				actual = append(actual, item.String())
+			}
+
+			assert.Equal(t, tc.expectedItems, actual)
+			assert.EqualError(t, iterErr, tc.expectedError)
+		})
+	}
+}
+
+func TestIterate(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`({"name": n, "size": len(n)} for n in ("a", "bb"))`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	type row struct {
+		Name string `python:"name"`
+		Size int    `python:"size"`
+	}
+
+	var actual []row
+
+	for v, err := range python3.Iterate[row](o) {
+		require.NoError(t, err)
+
+		actual = append(actual, v)
+	}
+
+	assert.Equal(t, []row{{Name: "a", Size: 1}, {Name: "bb", Size: 2}}, actual)
+}
+
+func TestIterate_Error(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`[1, "a", 3]`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	var (
+		actual  []int
+		iterErr error
+	)
+
+	for v, err := range python3.Iterate[int](o) {
+		if err != nil {
+			iterErr = err
+
+			continue
+		}
+
+		actual = append(actual, v)
+	}
+
+	assert.Equal(t, []int{1}, actual)
+	assert.EqualError(t, iterErr, "python3: cannot unmarshal str into Go value of type int")
+}
+
+func TestNewIterator(t *testing.T) {
+	withGIL(t)
+
+	it, err := python3.NewIterator(slices.Values([]string{"a", "b", "c"}))
+	require.NoError(t, err)
+
+	defer it.DecRef()
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("it", it)
+
+	err = python3.Exec("first = next(it)\nrest = list(it)\nexhausted = next(it, None) is None\n", globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a", globals.Get("first").String())
+	assert.Equal(t, "['b', 'c']", globals.Get("rest").String())
+	assert.True(t, python3.AsBool(globals.Get("exhausted")))
+}
+
+func TestNewIterator_Lazy(t *testing.T) {
+	withGIL(t)
+
+	var pulled int
+
+	seq := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	it := python3.MustNewIterator(iter.Seq[int](seq))
+	defer it.DecRef()
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("it", it)
+
+	err := python3.Exec("import itertools\nresult = list(itertools.islice(it, 3))\n", globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "[0, 1, 2]", globals.Get("result").String())
+	assert.Equal(t, 3, pulled)
+}
+
+func TestNewIterator_Panic(t *testing.T) {
+	withGIL(t)
+
+	seq := func(func(int) bool) {
+		panic("broken")
+	}
+
+	it := python3.MustNewIterator(iter.Seq[int](seq))
+	defer it.DecRef()
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	globals.Set("it", it)
+
+	err := python3.Exec("list(it)", globals, nil)
+
+	assert.EqualError(t, err, "next() panicked: broken")
+}
@@ -33,7 +33,7 @@ func (o *ListObject) Length() int {
 	return (*cpy3.PyObject)(o).Length()
 }
 
-// Set sets the item at index to value.
+// Set sets the item at index to value. A Python object value is stolen, like with PyList_SetItem.
 func (o *ListObject) Set(index int, value any) {
 	defer MustSuccess()
 
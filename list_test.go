@@ -11,6 +11,8 @@ import (
 )
 
 func TestList_DecRefNil(t *testing.T) {
+	withGIL(t)
+
 	var list *python3.AnyList
 
 	assert.NotPanics(t, func() {
@@ -19,6 +21,8 @@ func TestList_DecRefNil(t *testing.T) {
 }
 
 func TestList_IsList(t *testing.T) {
+	withGIL(t)
+
 	assert.True(t, python3.IsList(python3.NewList(10)))
 	assert.False(t, python3.IsList(python3.NewTuple(10)))
 	assert.False(t, python3.IsList(python3.NewBool(true)))
@@ -29,6 +33,8 @@ func TestList_IsList(t *testing.T) {
 }
 
 func TestList_Capacity(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewList(10)
 	defer list.DecRef()
 
@@ -43,6 +49,8 @@ func TestList_Capacity(t *testing.T) {
 }
 
 func TestList_Set(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewList(1)
 	defer list.DecRef()
 
@@ -52,14 +60,16 @@ func TestList_Set(t *testing.T) {
 		assert.Equal(t, 1, cpy3.PyLong_AsLong(cpy3.PyList_GetItem(list.PyObject(), 0)))
 	})
 
-	err := python3.IndexError{Exception: python3.Exception{Message: `list assignment index out of range`}}
+	err := python3.IndexError{LookupError: python3.LookupError{Exception: python3.Exception{Message: `list assignment index out of range`, Type: "IndexError"}}}
 
-	assert.PanicsWithValue(t, err, func() {
+	assertPanicsWithError(t, err, func() {
 		list.Set(1, 2)
 	})
 }
 
 func TestList_Get(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewList(1)
 	defer list.DecRef()
 
@@ -69,14 +79,16 @@ func TestList_Get(t *testing.T) {
 		assert.Equal(t, int64(1), list.Get(0))
 	})
 
-	err := python3.IndexError{Exception: python3.Exception{Message: `list index out of range`}}
+	err := python3.IndexError{LookupError: python3.LookupError{Exception: python3.Exception{Message: `list index out of range`, Type: "IndexError"}}}
 
-	assert.PanicsWithValue(t, err, func() {
+	assertPanicsWithError(t, err, func() {
 		list.Get(1)
 	})
 }
 
 func TestNewListForType(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewListForType[int](3)
 	defer list.DecRef()
 
@@ -91,6 +103,8 @@ func TestNewListForType(t *testing.T) {
 }
 
 func TestNewListFromValues(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewListFromValues(1, 2, 3)
 	defer list.DecRef()
 
@@ -104,6 +118,8 @@ func TestNewListFromValues(t *testing.T) {
 }
 
 func TestNewListFromAny(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewListFromAny(1, "hello", 3.14)
 	defer list.DecRef()
 
@@ -117,6 +133,8 @@ func TestNewListFromAny(t *testing.T) {
 }
 
 func TestListOfListOfList(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewListForType[[][]int](1)
 
 	list.Set(0, [][]int{{1, 2}, {2, 3}})
@@ -128,6 +146,8 @@ func TestListOfListOfList(t *testing.T) {
 }
 
 func TestListOfTuple(t *testing.T) {
+	withGIL(t)
+
 	list := python3.NewListForType[*python3.Tuple[int]](1)
 
 	list.Set(0, python3.NewTupleFromValues(1, 2))
@@ -141,6 +161,8 @@ func TestListOfTuple(t *testing.T) {
 }
 
 func TestListFromTuple(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTupleFromValues(1, 2, 3)
 
 	var list python3.List[int]
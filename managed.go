@@ -0,0 +1,109 @@
+package python
+
+import (
+	"runtime"
+	"sync"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// collected is the queue of the references of the garbage collected handles. The cleanups of the garbage collector run
+// on a goroutine that does not hold the GIL, so the references are released later by a thread that holds it.
+var collected struct {
+	sync.Mutex
+
+	refs []collectedRef
+}
+
+// collectedRef is the reference of a garbage collected handle, and the interpreter of the object.
+type collectedRef struct {
+	obj    *cpy3.PyObject
+	interp *Interpreter
+}
+
+// Managed is a handle that owns a reference to a Python object, and releases it when the handle is released or garbage
+// collected. Unlike a Scope, the object can outlive the function that created it without a matching DecRef call.
+//
+// The garbage collector does not hold the GIL, so the references of the collected handles are released by the next call
+// to Manage, WithGIL or Interpreter.Run in the interpreter of the object. The handle must stay reachable while the
+// object is used, and it must not outlive the interpreter.
+//
+//	h := python3.Manage(python3.MustMarshal([]int{1, 2, 3}))
+//
+//	fmt.Println(h.Get())
+type Managed[T PyObjector] struct {
+	obj     T
+	cleanup runtime.Cleanup
+}
+
+// Manage creates a Managed that owns the reference to the object. The GIL must be held.
+func Manage[T PyObjector](o T) *Managed[T] {
+	releaseCollected()
+
+	h := &Managed[T]{obj: o}
+
+	if isNil(o) || o.PyObject() == nil {
+		return h
+	}
+
+	h.cleanup = runtime.AddCleanup(h, collect, collectedRef{obj: o.PyObject(), interp: currentInterpreter()})
+
+	return h
+}
+
+// Get returns the object. The object is owned by the handle, and it must not be released with DecRef.
+func (h *Managed[T]) Get() T {
+	return h.obj
+}
+
+// Release releases the object right away, instead of waiting for the garbage collector. It does nothing if the object
+// is already released. The GIL must be held.
+func (h *Managed[T]) Release() {
+	if isNil(h.obj) || h.obj.PyObject() == nil {
+		return
+	}
+
+	h.cleanup.Stop()
+	h.obj.PyObject().DecRef()
+
+	var zero T
+
+	h.obj = zero
+}
+
+// collect queues the reference of a garbage collected handle.
+func collect(r collectedRef) {
+	collected.Lock()
+	defer collected.Unlock()
+
+	collected.refs = append(collected.refs, r)
+}
+
+// releaseCollected releases the references of the garbage collected handles of the current interpreter. The GIL must
+// be held.
+func releaseCollected() {
+	i := currentInterpreter()
+
+	collected.Lock()
+
+	var refs []*cpy3.PyObject
+
+	kept := collected.refs[:0]
+
+	for _, r := range collected.refs {
+		if r.interp == i {
+			refs = append(refs, r.obj)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+
+	collected.refs = kept
+
+	collected.Unlock()
+
+	// The objects are released without the lock, because their finalizers may create or collect other handles.
+	for _, o := range refs {
+		o.DecRef()
+	}
+}
@@ -0,0 +1,108 @@
+package python_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestManage_Release(t *testing.T) {
+	withGIL(t)
+
+	o := python3.MustMarshal([]int{1, 2, 3})
+
+	o.PyObject().IncRef()
+	defer o.DecRef()
+
+	h := python3.Manage(o)
+
+	assert.Same(t, o, h.Get())
+	assert.Equal(t, 2, refCount(t, o))
+
+	h.Release()
+
+	assert.Nil(t, h.Get())
+	assert.Equal(t, 1, refCount(t, o))
+
+	assert.NotPanics(t, h.Release)
+}
+
+func TestManage_Nil(t *testing.T) {
+	withGIL(t)
+
+	h := python3.Manage[*python3.Object](nil)
+
+	assert.Nil(t, h.Get())
+	assert.NotPanics(t, h.Release)
+}
+
+func TestManage_Collected(t *testing.T) {
+	withGIL(t)
+
+	list := python3.NewListFromValues(1, 2, 3)
+
+	list.PyObject().IncRef()
+	defer list.DecRef()
+
+	func() {
+		h := python3.Manage(list)
+
+		assert.Equal(t, 2, refCount(t, h.Get()))
+	}()
+
+	for range 100 {
+		runtime.GC()
+
+		// The references of the collected handles are released by the thread that holds the GIL.
+		require.NoError(t, python3.WithGIL(func() error { return nil }))
+
+		if refCount(t, list) == 1 {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, 1, refCount(t, list))
+}
+
+func TestManage_Interpreter(t *testing.T) {
+	withGIL(t)
+
+	interp := newInterpreter(t)
+
+	err := interp.Run(func() error {
+		o := python3.MustMarshal([]int{1, 2, 3})
+
+		o.PyObject().IncRef()
+		defer o.DecRef()
+
+		func() {
+			python3.Manage(o)
+		}()
+
+		for range 100 {
+			runtime.GC()
+
+			// Manage releases the references of the collected handles of the interpreter.
+			python3.Manage[*python3.Object](nil)
+
+			if refCount(t, o) == 1 {
+				break
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		assert.Equal(t, 1, refCount(t, o))
+
+		return nil
+	})
+
+	require.NoError(t, err)
+}
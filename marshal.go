@@ -2,8 +2,12 @@ package python
 
 import "C"
 import (
+	"encoding"
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
+	"time"
 
 	cpy3 "go.nhat.io/cpy/v3"
 )
@@ -20,12 +24,34 @@ type Unmarshaler interface {
 
 // Marshal returns the Python object for v.
 func Marshal(v any) (*Object, error) { //nolint: cyclop,funlen,gocyclo
+	if isNil(v) {
+		return newNone(), nil
+	}
+
 	if v, ok := v.(Marshaler); ok {
 		return v.MarshalPyObject(), nil
 	}
 
-	if v := reflect.ValueOf(v); v.Kind() == reflect.Ptr && v.IsNil() {
-		return nil, nil //nolint: nilnil
+	if v, ok := v.(DecimalMarshaler); ok {
+		return marshalDecimal(v)
+	}
+
+	// The pointers to the time types are dereferenced, otherwise the reflect fallback below would marshal them as structs
+	// or integers.
+	switch p := v.(type) {
+	case *time.Time:
+		v = *p
+
+	case *time.Duration:
+		v = *p
+
+	case *Date:
+		v = *p
+
+	case *TimeOfDay:
+		v = *p
+
+	default:
 	}
 
 	switch v := v.(type) {
@@ -82,11 +108,81 @@ func Marshal(v any) (*Object, error) { //nolint: cyclop,funlen,gocyclo
 
 	case float64:
 		return NewFloat64(v), nil
+
+	case complex64:
+		return NewComplex(complex128(v)), nil
+
+	case complex128:
+		return NewComplex(v), nil
+
+	case *big.Int:
+		return NewBigInt(v), nil
+
+	case big.Int:
+		return NewBigInt(&v), nil
+
+	case *big.Rat:
+		return NewFraction(v)
+
+	case big.Rat:
+		return NewFraction(&v)
+
+	case Decimal:
+		return NewDecimal(v)
+
+	case time.Time:
+		return NewDateTime(v)
+
+	case time.Duration:
+		return NewTimeDelta(v)
+
+	case Date:
+		return NewDate(v)
+
+	case TimeOfDay:
+		return NewTimeOfDay(v)
+	}
+
+	if o, ok, err := marshalInstance(reflect.ValueOf(v)); ok {
+		return o, err
 	}
 
 	rv := reflect.Indirect(reflect.ValueOf(v))
-	if rv.Kind() == reflect.Slice {
-		return marshalSlice(rv), nil
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return NewBool(rv.Bool()), nil
+
+	case reflect.String:
+		return NewString(rv.String()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewInt64(rv.Int()), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return NewUint64(rv.Uint()), nil
+
+	case reflect.Float32, reflect.Float64:
+		return NewFloat64(rv.Float()), nil
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return NewBytes(rv.Bytes()), nil
+		}
+
+		return marshalSlice(rv)
+
+	case reflect.Map:
+		if rv.Type().Elem() == emptyStructType {
+			return marshalSet(rv)
+		}
+
+		return marshalMap(rv)
+
+	case reflect.Struct:
+		return marshalStruct(rv)
+
+	default:
 	}
 
 	return nil, fmt.Errorf("cannot marshal value of %T to python object", v) //nolint: err113
@@ -102,14 +198,120 @@ func MustMarshal(v any) *Object {
 	return o
 }
 
-func marshalSlice(v reflect.Value) *Object {
-	l := make([]any, v.Cap(), v.Len())
+// marshalNewRef is like Marshal but always returns a new reference, even when v is already a Python object, so that the
+// result can be given to a function that steals the reference.
+func marshalNewRef(v any) (*Object, error) {
+	o, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if o == nil {
+		return newNone(), nil
+	}
+
+	switch v.(type) {
+	case *cpy3.PyObject, *Object, Objector, PyObjector:
+		o.PyObject().IncRef()
+	}
+
+	return o, nil
+}
+
+// isNil returns true if v is nil, or a nil pointer, slice, map or interface.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return rv.IsNil()
+
+	default:
+		return false
+	}
+}
+
+// marshalSlice converts a slice to a Python list. PyList_SetItem steals the references, so the items are marshaled to
+// new references, even when they are already Python objects.
+func marshalSlice(v reflect.Value) (*Object, error) {
+	l := NewListObject(v.Len())
 
 	for i := range v.Len() {
-		l[i] = v.Index(i).Interface()
+		item, err := marshalNewRef(v.Index(i).Interface())
+		if err != nil {
+			l.DecRef()
+
+			return nil, err
+		}
+
+		cpy3.PyList_SetItem(l.PyObject(), i, item.PyObject())
+	}
+
+	return l.AsObject(), nil
+}
+
+func marshalMap(v reflect.Value) (*Object, error) {
+	d := NewDictObject()
+	iter := v.MapRange()
+
+	for iter.Next() {
+		if err := setDictItem(d, iter.Key().Interface(), iter.Value().Interface()); err != nil {
+			d.DecRef()
+
+			return nil, err
+		}
+	}
+
+	return d.AsObject(), nil
+}
+
+// marshalSet converts a map[T]struct{} to a Python set of its keys.
+func marshalSet(v reflect.Value) (*Object, error) {
+	set := NewSetObject()
+	iter := v.MapRange()
+
+	for iter.Next() {
+		item, err := marshalNewRef(iter.Key().Interface())
+		if err == nil {
+			err = set.add(item)
+
+			item.DecRef()
+		}
+
+		if err != nil {
+			set.DecRef()
+
+			return nil, err
+		}
+	}
+
+	return set.AsObject(), nil
+}
+
+// setDictItem marshals the key and the value, and sets them into the dict. PyDict_SetItem does not steal the
+// references, so they are released afterward.
+func setDictItem(d *DictObject, k, v any) error {
+	key, err := marshalNewRef(k)
+	if err != nil {
+		return err
+	}
+
+	defer key.DecRef()
+
+	value, err := marshalNewRef(v)
+	if err != nil {
+		return err
+	}
+
+	defer value.DecRef()
+
+	if cpy3.PyDict_SetItem(d.PyObject(), key.PyObject(), value.PyObject()) != 0 {
+		return LastError()
 	}
 
-	return NewListFromAny(l...).AsObject()
+	return nil
 }
 
 // An InvalidUnmarshalError describes an invalid argument passed to [Unmarshal].
@@ -146,8 +348,58 @@ func (e *UnmarshalTypeError) Error() string {
 	return "python3: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
 }
 
+// UnmarshalOption configures how a Python object is unmarshaled.
+type UnmarshalOption func(d *decoder)
+
+// WithAttributes fills the fields of Go structs from the attributes of the Python object, such as dataclasses,
+// namedtuples or plain objects, instead of the items of a dict. The attributes are looked up by the same names as the
+// dict keys. A missing attribute is reported as an UnmarshalTypeError. Dicts are still unmarshaled by their items.
+func WithAttributes() UnmarshalOption {
+	return func(d *decoder) {
+		d.attributes = true
+	}
+}
+
+// WithLenientNumbers allows the numeric coercions that Python allows: an int into a Go float, an int or a float into a
+// Go complex, an integral float into a Go integer, and a bool into a Go integer. By default, the Python type must match
+// the kind of the Go type. The values are range-checked either way.
+func WithLenientNumbers() UnmarshalOption {
+	return func(d *decoder) {
+		d.lenientNumbers = true
+	}
+}
+
+// WithNaiveLocation sets the location of the naive Python datetimes, which have no time zone, when they are unmarshaled
+// into time.Time. By default, they are in UTC. The aware datetimes keep their own offset.
+func WithNaiveLocation(loc *time.Location) UnmarshalOption {
+	return func(d *decoder) {
+		d.naiveLocation = loc
+	}
+}
+
+// decoder holds the options of an Unmarshal call.
+type decoder struct {
+	attributes     bool
+	lenientNumbers bool
+	naiveLocation  *time.Location
+}
+
+func newDecoder(opts ...UnmarshalOption) *decoder {
+	d := &decoder{naiveLocation: time.UTC}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
 // Unmarshal converts the Python object to a value of the same type as v.
-func Unmarshal(o *Object, v any) error { //nolint: cyclop,funlen,gocognit,gocyclo
+func Unmarshal(o *Object, v any, opts ...UnmarshalOption) error {
+	return newDecoder(opts...).unmarshal(o, v)
+}
+
+func (d *decoder) unmarshal(o *Object, v any) error { //nolint: cyclop,funlen,gocognit,gocyclo
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Pointer || rv.IsNil() {
 		return &InvalidUnmarshalError{reflect.TypeOf(v)}
@@ -157,12 +409,69 @@ func Unmarshal(o *Object, v any) error { //nolint: cyclop,funlen,gocognit,gocycl
 		return u.UnmarshalPyObject(o)
 	}
 
+	if u, ok := v.(encoding.TextUnmarshaler); ok && IsDecimal(o) {
+		return u.UnmarshalText([]byte(o.String()))
+	}
+
 	irv := reflect.Indirect(rv)
+
+	if IsNone(o) {
+		switch irv.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			irv.SetZero()
+
+			return nil
+
+		default:
+		}
+	}
+
+	if irv.Type() == bigIntType {
+		v, err := asBigInt(o)
+		if err != nil {
+			return err
+		}
+
+		irv.Set(reflect.ValueOf(v).Elem())
+
+		return nil
+	}
+
+	if irv.Type() == ratType {
+		v, err := asRat(o)
+		if err != nil {
+			return err
+		}
+
+		irv.Set(reflect.ValueOf(v).Elem())
+
+		return nil
+	}
+
+	if irv.Type() == decimalType {
+		v, err := unmarshalDecimal(o)
+		if err != nil {
+			return err
+		}
+
+		irv.SetString(string(v))
+
+		return nil
+	}
+
+	if ok, err := d.unmarshalTime(o, irv); ok {
+		return err
+	}
+
 	kind := irv.Kind()
 	targetKind := kind
 
 	if irv.Type() == reflect.TypeOf((*any)(nil)).Elem() {
 		targetKind = objectKind(o)
+
+		if targetKind == reflect.Invalid {
+			return d.unmarshalOther(o, irv)
+		}
 	}
 
 	switch targetKind {
@@ -195,54 +504,108 @@ func Unmarshal(o *Object, v any) error { //nolint: cyclop,funlen,gocognit,gocycl
 		return nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v, err := unmarshalInt(o)
+		if kind != targetKind {
+			return unmarshalIntInterface(o, irv)
+		}
+
+		v, err := d.unmarshalInt(o, irv.Type())
 		if err != nil {
 			return err
 		}
 
-		if kind != targetKind {
-			irv.Set(reflect.ValueOf(v))
-		} else {
-			irv.SetInt(v)
+		if irv.OverflowInt(v) {
+			return newOverflowError(o, irv.Type())
 		}
 
+		irv.SetInt(v)
+
 		return nil
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v, err := unmarshalUint(o)
+		v, err := d.unmarshalUint(o, irv.Type())
+		if err != nil {
+			return err
+		}
+
+		if irv.OverflowUint(v) {
+			return newOverflowError(o, irv.Type())
+		}
+
+		irv.SetUint(v)
+
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		v, err := d.unmarshalFloat64(o, irv.Type())
 		if err != nil {
 			return err
 		}
 
 		if kind != targetKind {
 			irv.Set(reflect.ValueOf(v))
-		} else {
-			irv.SetUint(v)
+
+			return nil
+		}
+
+		if irv.OverflowFloat(v) {
+			return newOverflowError(o, irv.Type())
 		}
 
+		irv.SetFloat(v)
+
 		return nil
 
-	case reflect.Float32, reflect.Float64:
-		v, err := unmarshalFloat64(o)
+	case reflect.Complex64, reflect.Complex128:
+		v, err := d.unmarshalComplex128(o, irv.Type())
 		if err != nil {
 			return err
 		}
 
 		if kind != targetKind {
 			irv.Set(reflect.ValueOf(v))
-		} else {
-			irv.SetFloat(v)
+
+			return nil
 		}
 
+		if irv.OverflowComplex(v) {
+			return newOverflowError(o, irv.Type())
+		}
+
+		irv.SetComplex(v)
+
 		return nil
 
 	case reflect.Slice:
-		return unmarshalSlice(o, irv)
+		if IsBytes(o) || IsByteArray(o) {
+			return unmarshalBytes(o, irv)
+		}
+
+		if kind != targetKind {
+			return d.unmarshalInterface(o, irv, reflect.TypeFor[[]any]())
+		}
+
+		return d.unmarshalSlice(o, irv)
+
+	case reflect.Map:
+		if kind != targetKind {
+			return d.unmarshalInterface(o, irv, dictType(o))
+		}
+
+		return d.unmarshalMap(o, irv)
+
+	case reflect.Struct:
+		return d.unmarshalStruct(o, irv)
 
 	case reflect.Pointer:
+		if gv, ok := goValueOf(o); ok && reflect.TypeOf(gv) == irv.Type() {
+			irv.Set(reflect.ValueOf(gv))
+
+			return nil
+		}
+
 		p := reflect.New(irv.Type().Elem())
 
-		if err := Unmarshal(o, p.Interface()); err != nil {
+		if err := d.unmarshal(o, p.Interface()); err != nil {
 			return err
 		}
 
@@ -256,11 +619,26 @@ func Unmarshal(o *Object, v any) error { //nolint: cyclop,funlen,gocognit,gocycl
 	return &UnmarshalTypeError{Value: TypeName(o), Type: irv.Type()}
 }
 
+// isScalarStruct returns true if the struct type t is unmarshaled from a single Python object, rather than from the
+// items of a dict or the attributes of an object.
+func isScalarStruct(t reflect.Type) bool {
+	switch t {
+	case bigIntType, ratType, timeType, dateType, timeOfDayType:
+		return true
+
+	default:
+	}
+
+	p := reflect.PointerTo(t)
+
+	return p.Implements(reflect.TypeFor[Unmarshaler]()) || p.Implements(reflect.TypeFor[encoding.TextUnmarshaler]())
+}
+
 // UnmarshalAs converts the Python object to a value of the same type as T.
-func UnmarshalAs[T any](o *Object) (T, error) {
+func UnmarshalAs[T any](o *Object, opts ...UnmarshalOption) (T, error) {
 	var v T
 
-	if err := Unmarshal(o, &v); err != nil {
+	if err := Unmarshal(o, &v, opts...); err != nil {
 		return v, err
 	}
 
@@ -268,23 +646,24 @@ func UnmarshalAs[T any](o *Object) (T, error) {
 }
 
 // MustUnmarshal converts the Python object to a value of the same type as v or panics if an error occurs.
-func MustUnmarshal(o *Object, v any) {
-	if err := Unmarshal(o, v); err != nil {
+func MustUnmarshal(o *Object, v any, opts ...UnmarshalOption) {
+	if err := Unmarshal(o, v, opts...); err != nil {
 		panic(err)
 	}
 }
 
 // MustUnmarshalAs converts the Python object to a value of the same type as T or panics if an error occurs.
-func MustUnmarshalAs[T any](o *Object) T {
+func MustUnmarshalAs[T any](o *Object, opts ...UnmarshalOption) T {
 	var v T
 
-	MustUnmarshal(o, &v)
+	MustUnmarshal(o, &v, opts...)
 
 	return v
 }
 
+// unmarshalString converts a Python str, or the string representation of a decimal or a fraction.
 func unmarshalString(o *Object) (string, error) {
-	if !IsString(o) {
+	if !IsString(o) && !IsDecimal(o) && !IsFraction(o) {
 		return "", &UnmarshalTypeError{Value: TypeName(o), Type: reflect.TypeFor[string]()}
 	}
 
@@ -299,31 +678,230 @@ func unmarshalBool(o *Object) (bool, error) {
 	return AsBool(o), nil
 }
 
-func unmarshalInt(o *Object) (int64, error) {
+func unmarshalInt(o *Object, t reflect.Type) (int64, error) {
 	if !IsInt(o) {
-		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: reflect.TypeFor[int64]()}
+		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: t}
+	}
+
+	v, ok := asInt64AndOverflow(o)
+	if !ok {
+		return 0, newOverflowError(o, t)
+	}
+
+	return v, nil
+}
+
+// unmarshalIntInterface converts a Python int to an int64, or to a *big.Int if it does not fit.
+func unmarshalIntInterface(o *Object, dest reflect.Value) error {
+	if v, ok := asInt64AndOverflow(o); ok {
+		dest.Set(reflect.ValueOf(v))
+
+		return nil
+	}
+
+	v, err := asBigInt(o)
+	if err != nil {
+		return err
+	}
+
+	dest.Set(reflect.ValueOf(v))
+
+	return nil
+}
+
+// unmarshalOther converts a Python object that has no matching kind, see objectKind, to an interface. The datetime
+// types are only probed here, so that the common objects do not pay for them.
+func (d *decoder) unmarshalOther(o *Object, dest reflect.Value) error {
+	var t reflect.Type
+
+	switch {
+	case IsDateTime(o):
+		t = timeType
+
+	case IsDate(o):
+		t = dateType
+
+	case IsTime(o):
+		t = timeOfDayType
+
+	case IsTimeDelta(o):
+		t = durationType
+
+	default:
+		return unmarshalNumberInterface(o, dest)
 	}
 
-	return AsInt64(o), nil
+	return d.unmarshalInterface(o, dest, t)
 }
 
-func unmarshalUint(o *Object) (uint64, error) {
+// unmarshalNumberInterface converts a Python decimal to a Decimal, or a Python fraction to a *big.Rat.
+func unmarshalNumberInterface(o *Object, dest reflect.Value) error {
+	switch {
+	case IsDecimal(o):
+		dest.Set(reflect.ValueOf(Decimal(o.String())))
+
+	case IsFraction(o):
+		v, err := asRat(o)
+		if err != nil {
+			return err
+		}
+
+		dest.Set(reflect.ValueOf(v))
+
+	default:
+		return &UnmarshalTypeError{Value: TypeName(o), Type: dest.Type()}
+	}
+
+	return nil
+}
+
+func unmarshalUint(o *Object, t reflect.Type) (uint64, error) {
 	if !IsInt(o) {
-		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: reflect.TypeFor[uint64]()}
+		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: t}
+	}
+
+	v, ok := asUint64AndOverflow(o)
+	if !ok {
+		return 0, newOverflowError(o, t)
+	}
+
+	return v, nil
+}
+
+// unmarshalInt converts a Python int to an int64. In lenient mode, it also converts a bool or an integral float.
+func (d *decoder) unmarshalInt(o *Object, t reflect.Type) (int64, error) {
+	if d.lenientNumbers {
+		switch {
+		case IsBool(o):
+			return boolToInt64(AsBool(o)), nil
+
+		case IsFloat(o):
+			f, err := integralFloat(o, t)
+			if err != nil {
+				return 0, err
+			}
+
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return 0, newOverflowError(o, t)
+			}
+
+			return int64(f), nil
+		}
+	}
+
+	return unmarshalInt(o, t)
+}
+
+// unmarshalUint converts a Python int to an uint64. In lenient mode, it also converts a bool or an integral float.
+func (d *decoder) unmarshalUint(o *Object, t reflect.Type) (uint64, error) {
+	if d.lenientNumbers {
+		switch {
+		case IsBool(o):
+			return uint64(boolToInt64(AsBool(o))), nil
+
+		case IsFloat(o):
+			f, err := integralFloat(o, t)
+			if err != nil {
+				return 0, err
+			}
+
+			if f < 0 || f >= math.MaxUint64 {
+				return 0, newOverflowError(o, t)
+			}
+
+			return uint64(f), nil
+		}
+	}
+
+	return unmarshalUint(o, t)
+}
+
+// unmarshalFloat64 converts a Python float to a float64. In lenient mode, it also converts an int.
+func (d *decoder) unmarshalFloat64(o *Object, t reflect.Type) (float64, error) {
+	if d.lenientNumbers && IsInt(o) {
+		v := cpy3.PyLong_AsDouble(o.PyObject())
+		if err := LastError(); err != nil {
+			return 0, err
+		}
+
+		return v, nil
+	}
+
+	return unmarshalFloat64(o, t)
+}
+
+// integralFloat returns the value of a Python float that has no fractional part.
+func integralFloat(o *Object, t reflect.Type) (float64, error) {
+	f := AsFloat64(o)
+
+	if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+		return 0, &UnmarshalTypeError{Value: "float " + o.String(), Type: t}
 	}
 
-	return AsUint64(o), nil
+	return f, nil
 }
 
-func unmarshalFloat64(o *Object) (float64, error) {
+func boolToInt64(v bool) int64 {
+	if v {
+		return 1
+	}
+
+	return 0
+}
+
+func unmarshalFloat64(o *Object, t reflect.Type) (float64, error) {
 	if !IsFloat(o) {
-		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: reflect.TypeFor[float64]()}
+		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: t}
 	}
 
 	return AsFloat64(o), nil
 }
 
-func unmarshalSlice(o *Object, dest reflect.Value) error {
+// unmarshalComplex128 converts a Python complex to a complex128. In lenient mode, it also converts an int or a float.
+func (d *decoder) unmarshalComplex128(o *Object, t reflect.Type) (complex128, error) {
+	if d.lenientNumbers && (IsInt(o) || IsFloat(o)) {
+		v, err := d.unmarshalFloat64(o, t)
+		if err != nil {
+			return 0, err
+		}
+
+		return complex(v, 0), nil
+	}
+
+	return unmarshalComplex128(o, t)
+}
+
+func unmarshalComplex128(o *Object, t reflect.Type) (complex128, error) {
+	if !IsComplex(o) {
+		return 0, &UnmarshalTypeError{Value: TypeName(o), Type: t}
+	}
+
+	return AsComplex128(o), nil
+}
+
+func unmarshalBytes(o *Object, dest reflect.Value) error {
+	switch {
+	case dest.Kind() == reflect.Interface:
+		dest.Set(reflect.ValueOf(AsBytes(o)))
+
+	case dest.Type().Elem().Kind() == reflect.Uint8:
+		dest.SetBytes(AsBytes(o))
+
+	default:
+		return &UnmarshalTypeError{Value: TypeName(o), Type: dest.Type()}
+	}
+
+	return nil
+}
+
+func (d *decoder) unmarshalSlice(o *Object, dest reflect.Value) error {
+	if IsSet(o) {
+		list := (*SetObject)(o).AsList()
+		defer list.DecRef()
+
+		return d.unmarshalSlice(list.AsObject(), dest)
+	}
+
 	if !IsList(o) && !IsTuple(o) {
 		return &UnmarshalTypeError{Value: TypeName(o), Type: dest.Type()}
 	}
@@ -342,7 +920,7 @@ func unmarshalSlice(o *Object, dest reflect.Value) error {
 
 		defers = append(defers, item.DecRef)
 
-		if err := Unmarshal(item, v.Index(i).Addr().Interface()); err != nil {
+		if err := d.unmarshal(item, v.Index(i).Addr().Interface()); err != nil {
 			return err
 		}
 	}
@@ -352,6 +930,118 @@ func unmarshalSlice(o *Object, dest reflect.Value) error {
 	return nil
 }
 
+func (d *decoder) unmarshalMap(o *Object, dest reflect.Value) error {
+	if IsSet(o) && dest.Type().Elem() == emptyStructType {
+		return d.unmarshalSet(o, dest)
+	}
+
+	if !IsDict(o) {
+		return &UnmarshalTypeError{Value: TypeName(o), Type: dest.Type()}
+	}
+
+	var err error
+
+	dict := (*DictObject)(o)
+	t := dest.Type()
+	m := reflect.MakeMapWithSize(t, dict.Length())
+
+	dict.forEach(func(key, value *Object) {
+		if err != nil {
+			return
+		}
+
+		k := reflect.New(t.Key())
+		if err = d.unmarshal(key, k.Interface()); err != nil {
+			return
+		}
+
+		if !k.Elem().Comparable() {
+			err = &UnmarshalTypeError{Value: "unhashable key " + TypeName(key), Type: t}
+
+			return
+		}
+
+		v := reflect.New(t.Elem())
+		if err = d.unmarshal(value, v.Interface()); err != nil {
+			return
+		}
+
+		m.SetMapIndex(k.Elem(), v.Elem())
+	})
+
+	if err != nil {
+		return err
+	}
+
+	dest.Set(m)
+
+	return nil
+}
+
+// unmarshalSet unmarshals the items of a Python set into the keys of a map[T]struct{}.
+func (d *decoder) unmarshalSet(o *Object, dest reflect.Value) error {
+	var err error
+
+	set := (*SetObject)(o)
+	t := dest.Type()
+	m := reflect.MakeMapWithSize(t, set.Length())
+
+	set.forEach(func(item *Object) {
+		if err != nil {
+			return
+		}
+
+		k := reflect.New(t.Key())
+		if err = d.unmarshal(item, k.Interface()); err != nil {
+			return
+		}
+
+		if !k.Elem().Comparable() {
+			err = &UnmarshalTypeError{Value: "unhashable item " + TypeName(item), Type: t}
+
+			return
+		}
+
+		m.SetMapIndex(k.Elem(), reflect.ValueOf(struct{}{}))
+	})
+
+	if err != nil {
+		return err
+	}
+
+	dest.Set(m)
+
+	return nil
+}
+
+// unmarshalInterface unmarshals the Python object into a new value of type t and stores it in the interface dest.
+func (d *decoder) unmarshalInterface(o *Object, dest reflect.Value, t reflect.Type) error {
+	v := reflect.New(t)
+
+	if err := d.unmarshal(o, v.Interface()); err != nil {
+		return err
+	}
+
+	dest.Set(v.Elem())
+
+	return nil
+}
+
+// dictType returns map[string]any if all the keys of the dict are strings, otherwise map[any]any.
+func dictType(o *Object) reflect.Type {
+	allStrings := true
+
+	(*DictObject)(o).forEach(func(key, _ *Object) {
+		allStrings = allStrings && IsString(key)
+	})
+
+	if allStrings {
+		return reflect.TypeFor[map[string]any]()
+	}
+
+	return reflect.TypeFor[map[any]any]()
+}
+
 func objectKind(o *Object) reflect.Kind {
 	if IsBool(o) {
 		return reflect.Bool
@@ -365,13 +1055,21 @@ func objectKind(o *Object) reflect.Kind {
 		return reflect.Float64
 	}
 
+	if IsComplex(o) {
+		return reflect.Complex128
+	}
+
 	if IsString(o) {
 		return reflect.String
 	}
 
-	if IsList(o) || IsTuple(o) {
+	if IsList(o) || IsTuple(o) || IsSet(o) || IsBytes(o) || IsByteArray(o) {
 		return reflect.Slice
 	}
 
+	if IsDict(o) {
+		return reflect.Map
+	}
+
 	return reflect.Invalid
 }
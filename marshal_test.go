@@ -1,6 +1,8 @@
 package python_test
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -11,6 +13,8 @@ import (
 )
 
 func TestMarshal(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario       string
 		value          any
@@ -72,6 +76,46 @@ func TestMarshal(t *testing.T) {
 			value:          3.14,
 			expectedResult: python3.NewFloat64(3.14),
 		},
+		{
+			scenario:       "complex64",
+			value:          complex64(complex(1, -2)),
+			expectedResult: python3.NewComplex(complex(1, -2)),
+		},
+		{
+			scenario:       "complex128",
+			value:          complex(0.5, 3),
+			expectedResult: python3.NewComplex(complex(0.5, 3)),
+		},
+		{
+			scenario:       "named bool",
+			value:          flag(true),
+			expectedResult: python3.NewBool(true),
+		},
+		{
+			scenario:       "named string",
+			value:          label("hello"),
+			expectedResult: python3.NewString("hello"),
+		},
+		{
+			scenario:       "named int",
+			value:          status(-1),
+			expectedResult: python3.NewInt64(-1),
+		},
+		{
+			scenario:       "named uint",
+			value:          level(200),
+			expectedResult: python3.NewUint64(200),
+		},
+		{
+			scenario:       "named float",
+			value:          ratio(0.5),
+			expectedResult: python3.NewFloat64(0.5),
+		},
+		{
+			scenario:       "pointer to named int",
+			value:          new(status),
+			expectedResult: python3.NewInt64(0),
+		},
 		{
 			scenario:       "[]int",
 			value:          []int{1, 2, 3},
@@ -82,6 +126,11 @@ func TestMarshal(t *testing.T) {
 			value:          []integer{1, 2, 3},
 			expectedResult: python3.NewListFromValues(integer(1), integer(2), integer(3)).AsObject(),
 		},
+		{
+			scenario:       "[]byte",
+			value:          []byte("hello"),
+			expectedResult: python3.NewBytes([]byte("hello")),
+		},
 		{
 			scenario:      "unsupported",
 			value:         make(chan struct{}),
@@ -90,7 +139,7 @@ func TestMarshal(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			actual, err := python3.Marshal(tc.value)
 
 			if tc.expectedError == "" {
@@ -105,6 +154,8 @@ func TestMarshal(t *testing.T) {
 }
 
 func TestMustMarshal(t *testing.T) {
+	withGIL(t)
+
 	assert.NotPanics(t, func() {
 		python3.MustMarshal(42)
 	})
@@ -115,6 +166,8 @@ func TestMustMarshal(t *testing.T) {
 }
 
 func TestUnmarshal_CannotUnmarshalNonPointer(t *testing.T) {
+	withGIL(t)
+
 	var actual string
 
 	err := python3.Unmarshal(python3.NewString(""), actual)
@@ -123,18 +176,24 @@ func TestUnmarshal_CannotUnmarshalNonPointer(t *testing.T) {
 }
 
 func TestUnmarshal_CannotUnmarshalNil(t *testing.T) {
+	withGIL(t)
+
 	err := python3.Unmarshal(python3.NewString(""), nil)
 
 	require.EqualError(t, err, "python3: Unmarshal(nil)")
 }
 
 func TestUnmarshal_CannotUnmarshalNilInterface(t *testing.T) {
+	withGIL(t)
+
 	err := python3.Unmarshal(python3.NewString(""), python3.Unmarshaler(nil))
 
 	require.EqualError(t, err, "python3: Unmarshal(nil)")
 }
 
 func TestUnmarshal_Bool(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario       string
 		object         *python3.Object
@@ -164,7 +223,7 @@ func TestUnmarshal_Bool(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			var actual bool
 
 			err := python3.Unmarshal(tc.object, &actual)
@@ -181,6 +240,8 @@ func TestUnmarshal_Bool(t *testing.T) {
 }
 
 func TestUnmarshal_String(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario       string
 		object         *python3.Object
@@ -205,7 +266,7 @@ func TestUnmarshal_String(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			var actual string
 
 			err := python3.Unmarshal(tc.object, &actual)
@@ -222,6 +283,8 @@ func TestUnmarshal_String(t *testing.T) {
 }
 
 func TestUnmarshal_Int(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario       string
 		object         *python3.Object
@@ -232,25 +295,37 @@ func TestUnmarshal_Int(t *testing.T) {
 			scenario:       "bool",
 			object:         python3.True,
 			expectedResult: 0,
-			expectedError:  `python3: cannot unmarshal bool into Go value of type int64`,
+			expectedError:  `python3: cannot unmarshal bool into Go value of type int`,
 		},
 		{
 			scenario:       "empty string",
 			object:         python3.NewString(""),
 			expectedResult: 0,
-			expectedError:  `python3: cannot unmarshal str into Go value of type int64`,
+			expectedError:  `python3: cannot unmarshal str into Go value of type int`,
 		},
 		{
 			scenario:       "string",
 			object:         python3.NewString("hello"),
 			expectedResult: 0,
-			expectedError:  `python3: cannot unmarshal str into Go value of type int64`,
+			expectedError:  `python3: cannot unmarshal str into Go value of type int`,
 		},
 		{
 			scenario:       "float",
 			object:         python3.NewFloat64(3.14),
 			expectedResult: 0,
-			expectedError:  `python3: cannot unmarshal float into Go value of type int64`,
+			expectedError:  `python3: cannot unmarshal float into Go value of type int`,
+		},
+		{
+			scenario:       "string into int8",
+			object:         python3.NewString("42"),
+			expectedResult: int8(0),
+			expectedError:  `python3: cannot unmarshal str into Go value of type int8`,
+		},
+		{
+			scenario:       "string into uint16",
+			object:         python3.NewString("42"),
+			expectedResult: uint16(0),
+			expectedError:  `python3: cannot unmarshal str into Go value of type uint16`,
 		},
 		{
 			scenario:       "int",
@@ -302,10 +377,46 @@ func TestUnmarshal_Int(t *testing.T) {
 			object:         python3.NewUint(42),
 			expectedResult: uint64(42),
 		},
+		{
+			scenario:       "int8 overflow",
+			object:         python3.NewInt(300),
+			expectedResult: int8(0),
+			expectedError:  `python int 300 does not fit in Go value of type int8`,
+		},
+		{
+			scenario:       "uint16 overflow",
+			object:         python3.NewInt(70000),
+			expectedResult: uint16(0),
+			expectedError:  `python int 70000 does not fit in Go value of type uint16`,
+		},
+		{
+			scenario:       "int64 overflow",
+			object:         python3.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+			expectedResult: int64(0),
+			expectedError:  `python int 18446744073709551616 does not fit in Go value of type int64`,
+		},
+		{
+			scenario:       "uint64 overflow",
+			object:         python3.NewBigInt(new(big.Int).Lsh(big.NewInt(1), 64)),
+			expectedResult: uint64(0),
+			expectedError:  `python int 18446744073709551616 does not fit in Go value of type uint64`,
+		},
+		{
+			scenario:       "negative uint",
+			object:         python3.NewInt(-1),
+			expectedResult: uint(0),
+			expectedError:  `python int -1 does not fit in Go value of type uint`,
+		},
+		{
+			scenario:       "negative uint8",
+			object:         python3.NewInt(-1),
+			expectedResult: uint8(0),
+			expectedError:  `python int -1 does not fit in Go value of type uint8`,
+		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			actual := reflect.New(reflect.TypeOf(tc.expectedResult)).Interface()
 
 			err := python3.Unmarshal(tc.object, actual)
@@ -324,6 +435,8 @@ func TestUnmarshal_Int(t *testing.T) {
 }
 
 func TestUnmarshal_Float(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario       string
 		object         *python3.Object
@@ -348,6 +461,12 @@ func TestUnmarshal_Float(t *testing.T) {
 			expectedResult: 0.0,
 			expectedError:  `python3: cannot unmarshal int into Go value of type float64`,
 		},
+		{
+			scenario:       "int into float32",
+			object:         python3.NewInt(42),
+			expectedResult: float32(0),
+			expectedError:  `python3: cannot unmarshal int into Go value of type float32`,
+		},
 		{
 			scenario:       "float32",
 			object:         python3.NewFloat64(3.14),
@@ -358,10 +477,84 @@ func TestUnmarshal_Float(t *testing.T) {
 			object:         python3.NewFloat64(3.14),
 			expectedResult: 3.14,
 		},
+		{
+			scenario:       "float32 overflow",
+			object:         python3.NewFloat64(1e300),
+			expectedResult: float32(0),
+			expectedError:  `python float 1e+300 does not fit in Go value of type float32`,
+		},
+		{
+			scenario:       "float32 infinity",
+			object:         python3.NewFloat64(math.Inf(-1)),
+			expectedResult: float32(math.Inf(-1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			actual := reflect.New(reflect.TypeOf(tc.expectedResult)).Interface()
+
+			err := python3.Unmarshal(tc.object, actual)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+
+			actual = reflect.Indirect(reflect.ValueOf(actual)).Interface()
+
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestUnmarshal_Complex(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		object         *python3.Object
+		expectedResult any
+		expectedError  string
+	}{
+		{
+			scenario:       "float",
+			object:         python3.NewFloat64(3.14),
+			expectedResult: complex128(0),
+			expectedError:  `python3: cannot unmarshal float into Go value of type complex128`,
+		},
+		{
+			scenario:       "float into complex64",
+			object:         python3.NewFloat64(3.14),
+			expectedResult: complex64(0),
+			expectedError:  `python3: cannot unmarshal float into Go value of type complex64`,
+		},
+		{
+			scenario:       "complex64",
+			object:         python3.NewComplex(complex(1.5, -2)),
+			expectedResult: complex64(complex(1.5, -2)),
+		},
+		{
+			scenario:       "complex128",
+			object:         python3.NewComplex(complex(1.5, -2)),
+			expectedResult: complex(1.5, -2),
+		},
+		{
+			scenario:       "complex64 overflow",
+			object:         python3.NewComplex(complex(1, 1e300)),
+			expectedResult: complex64(0),
+			expectedError:  `python complex (1+1e+300j) does not fit in Go value of type complex64`,
+		},
+		{
+			scenario:       "interface",
+			object:         python3.NewComplex(complex(0, 1)),
+			expectedResult: any(complex(0, 1)),
+		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			actual := reflect.New(reflect.TypeOf(tc.expectedResult)).Interface()
 
 			err := python3.Unmarshal(tc.object, actual)
@@ -379,7 +572,157 @@ func TestUnmarshal_Float(t *testing.T) {
 	}
 }
 
+func TestUnmarshal_ComplexSlice(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Eval(`[1j, 2 - 3j]`, nil, nil)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	actual, err := python3.UnmarshalAs[[]complex64](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, []complex64{complex(0, 1), complex(2, -3)}, actual)
+
+	values, err := python3.UnmarshalAs[any](o)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{complex(0, 1), complex(2, -3)}, values)
+}
+
+func TestUnmarshal_LenientNumbers(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		expr           string
+		expectedResult any
+		expectedError  string
+	}{
+		{
+			scenario:       "int to float64",
+			expr:           `42`,
+			expectedResult: 42.0,
+		},
+		{
+			scenario:       "int to float32",
+			expr:           `-42`,
+			expectedResult: float32(-42),
+		},
+		{
+			scenario:       "wide int to float64",
+			expr:           `2 ** 2000`,
+			expectedResult: 0.0,
+			expectedError:  `int too large to convert to float`,
+		},
+		{
+			scenario:       "int to complex128",
+			expr:           `42`,
+			expectedResult: complex(42, 0),
+		},
+		{
+			scenario:       "float to complex64",
+			expr:           `-1.5`,
+			expectedResult: complex64(complex(-1.5, 0)),
+		},
+		{
+			scenario:       "integral float to int",
+			expr:           `42.0`,
+			expectedResult: 42,
+		},
+		{
+			scenario:       "integral float to uint8",
+			expr:           `255.0`,
+			expectedResult: uint8(255),
+		},
+		{
+			scenario:       "bool to int",
+			expr:           `True`,
+			expectedResult: 1,
+		},
+		{
+			scenario:       "bool to uint16",
+			expr:           `False`,
+			expectedResult: uint16(0),
+		},
+		{
+			scenario:       "fractional float to int",
+			expr:           `1.5`,
+			expectedResult: 0,
+			expectedError:  `python3: cannot unmarshal float 1.5 into Go value of type int`,
+		},
+		{
+			scenario:       "nan to int",
+			expr:           `float("nan")`,
+			expectedResult: int64(0),
+			expectedError:  `python3: cannot unmarshal float nan into Go value of type int64`,
+		},
+		{
+			scenario:       "infinity to uint",
+			expr:           `float("inf")`,
+			expectedResult: uint(0),
+			expectedError:  `python3: cannot unmarshal float inf into Go value of type uint`,
+		},
+		{
+			scenario:       "float overflow int8",
+			expr:           `300.0`,
+			expectedResult: int8(0),
+			expectedError:  `python float 300.0 does not fit in Go value of type int8`,
+		},
+		{
+			scenario:       "float overflow int64",
+			expr:           `1e19`,
+			expectedResult: int64(0),
+			expectedError:  `python float 1e+19 does not fit in Go value of type int64`,
+		},
+		{
+			scenario:       "negative float to uint",
+			expr:           `-1.0`,
+			expectedResult: uint(0),
+			expectedError:  `python float -1.0 does not fit in Go value of type uint`,
+		},
+		{
+			scenario:       "int overflow int8",
+			expr:           `300`,
+			expectedResult: int8(0),
+			expectedError:  `python int 300 does not fit in Go value of type int8`,
+		},
+		{
+			scenario:       "string to int",
+			expr:           `"42"`,
+			expectedResult: 0,
+			expectedError:  `python3: cannot unmarshal str into Go value of type int`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Eval(tc.expr, nil, nil)
+			require.NoError(t, err)
+
+			defer o.DecRef()
+
+			actual := reflect.New(reflect.TypeOf(tc.expectedResult)).Interface()
+
+			err = python3.Unmarshal(o, actual, python3.WithLenientNumbers())
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+
+			actual = reflect.Indirect(reflect.ValueOf(actual)).Interface()
+
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
 func TestUnmarshal_Slice(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario       string
 		object         *python3.Object
@@ -419,7 +762,7 @@ func TestUnmarshal_Slice(t *testing.T) {
 			scenario:       "list of string",
 			object:         python3.NewListFromValues("hello", "world").AsObject(),
 			expectedResult: []int(nil),
-			expectedError:  `python3: cannot unmarshal str into Go value of type int64`,
+			expectedError:  `python3: cannot unmarshal str into Go value of type int`,
 		},
 		{
 			scenario:       "list of int",
@@ -440,7 +783,7 @@ func TestUnmarshal_Slice(t *testing.T) {
 			scenario:       "tuple of string",
 			object:         python3.NewTupleFromValues("hello", "world").AsObject(),
 			expectedResult: []int(nil),
-			expectedError:  `python3: cannot unmarshal str into Go value of type int64`,
+			expectedError:  `python3: cannot unmarshal str into Go value of type int`,
 		},
 		{
 			scenario:       "tuple of int",
@@ -452,10 +795,26 @@ func TestUnmarshal_Slice(t *testing.T) {
 			object:         python3.NewTupleFromValues(integer(1), integer(2), integer(3)).AsObject(),
 			expectedResult: []integer{1, 2, 3},
 		},
+		{
+			scenario:       "bytes",
+			object:         python3.NewBytes([]byte("hello")),
+			expectedResult: []byte("hello"),
+		},
+		{
+			scenario:       "bytearray",
+			object:         python3.NewByteArray([]byte("hello")),
+			expectedResult: []byte("hello"),
+		},
+		{
+			scenario:       "bytes to []int",
+			object:         python3.NewBytes([]byte("hello")),
+			expectedResult: []int(nil),
+			expectedError:  `python3: cannot unmarshal bytes into Go value of type []int`,
+		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			actual := reflect.New(reflect.TypeOf(tc.expectedResult)).Interface()
 
 			err := python3.Unmarshal(tc.object, actual)
@@ -473,6 +832,321 @@ func TestUnmarshal_Slice(t *testing.T) {
 	}
 }
 
+func TestMarshal_Map(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario      string
+		value         any
+		expected      string
+		expectedError string
+	}{
+		{
+			scenario: "empty map",
+			value:    map[string]any{},
+			expected: `{}`,
+		},
+		{
+			scenario: "map[string]any",
+			value:    map[string]any{"name": "gopher", "age": 15, "height": 1.2, "tags": []string{"go"}},
+			expected: `{'age': 15, 'height': 1.2, 'name': 'gopher', 'tags': ['go']}`,
+		},
+		{
+			scenario: "map[int]float64",
+			value:    map[int]float64{1: 1.5, 2: 2.5},
+			expected: `{1: 1.5, 2: 2.5}`,
+		},
+		{
+			scenario: "nested map",
+			value:    map[string]map[string]int{"a": {"b": 1}},
+			expected: `{'a': {'b': 1}}`,
+		},
+		{
+			scenario: "pointer to map",
+			value:    &map[string]bool{"ok": true},
+			expected: `{'ok': True}`,
+		},
+		{
+			scenario: "named scalar types",
+			value:    map[label]status{"active": 1, "deleted": -1},
+			expected: `{'active': 1, 'deleted': -1}`,
+		},
+		{
+			scenario:      "unsupported value",
+			value:         map[string]any{"ch": make(chan struct{})},
+			expectedError: "cannot marshal value of chan struct {} to python object",
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			actual, err := python3.Marshal(tc.value)
+
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, actual)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, python3.IsDict(actual))
+
+			expected := python3.MustImportModule("ast").CallMethodArgs("literal_eval", tc.expected)
+			defer expected.DecRef()
+
+			assert.True(t, expected.Equal(actual), "expected %s, got %s", tc.expected, actual.String())
+		})
+	}
+}
+
+func TestMarshal_Map_References(t *testing.T) {
+	withGIL(t)
+
+	item := python3.NewList(0)
+	defer item.DecRef()
+
+	before := refCount(t, item)
+
+	o, err := python3.Marshal(map[string]any{"item": item, "value": 1 << 40})
+	require.NoError(t, err)
+
+	assert.Equal(t, before+1, refCount(t, item))
+
+	value := o.GetItem("value")
+	defer value.DecRef()
+
+	// The dict and the GetItem result.
+	assert.Equal(t, 2, refCount(t, value))
+
+	o.DecRef()
+
+	assert.Equal(t, before, refCount(t, item))
+}
+
+func TestMarshal_Slice_References(t *testing.T) {
+	withGIL(t)
+
+	item := python3.NewList(0)
+	defer item.DecRef()
+
+	before := refCount(t, item)
+
+	o, err := python3.Marshal([]*python3.Object{item.AsObject()})
+	require.NoError(t, err)
+
+	assert.Equal(t, before+1, refCount(t, item))
+
+	o.DecRef()
+
+	assert.Equal(t, before, refCount(t, item))
+}
+
+func TestMarshal_Slice_Capacity(t *testing.T) {
+	withGIL(t)
+
+	values := make([]int, 2, 8)
+	values[0], values[1] = 1, 2
+
+	o, err := python3.Marshal(values)
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.Equal(t, "[1, 2]", o.String())
+}
+
+func TestMarshal_Slice_Error(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		value    any
+	}{
+		{
+			scenario: "item",
+			value:    []any{make(chan int)},
+		},
+		{
+			scenario: "map value",
+			value:    map[string]any{"items": []any{make(chan int)}},
+		},
+		{
+			scenario: "struct field",
+			value:    struct{ Items []any }{Items: []any{make(chan int)}},
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o, err := python3.Marshal(tc.value)
+
+			assert.Nil(t, o)
+			require.EqualError(t, err, "cannot marshal value of chan int to python object")
+		})
+	}
+}
+
+func TestTryCallMethod_UnmarshalableArgument(t *testing.T) {
+	withGIL(t)
+
+	builtins := python3.MustImportModule("builtins")
+
+	assert.NotPanics(t, func() {
+		o, err := builtins.TryCallMethod("len", []any{make(chan int)})
+
+		assert.Nil(t, o)
+		require.EqualError(t, err, "cannot marshal value of chan int to python object")
+	})
+}
+
+func TestUnmarshal_Map(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		object         *python3.Object
+		expectedResult any
+		expectedError  string
+	}{
+		{
+			scenario:       "int",
+			object:         python3.NewInt(42),
+			expectedResult: map[string]int(nil),
+			expectedError:  `python3: cannot unmarshal int into Go value of type map[string]int`,
+		},
+		{
+			scenario:       "list",
+			object:         python3.NewListFromValues(1, 2).AsObject(),
+			expectedResult: map[string]int(nil),
+			expectedError:  `python3: cannot unmarshal list into Go value of type map[string]int`,
+		},
+		{
+			scenario:       "empty dict",
+			object:         python3.NewDict().AsObject(),
+			expectedResult: map[string]int{},
+		},
+		{
+			scenario:       "map[string]int",
+			object:         python3.NewDictFromMap(map[string]int{"one": 1, "two": 2}).AsObject(),
+			expectedResult: map[string]int{"one": 1, "two": 2},
+		},
+		{
+			scenario:       "map[int]float64",
+			object:         python3.NewDictFromMap(map[int]float64{1: 1.5, 2: 2.5}).AsObject(),
+			expectedResult: map[int]float64{1: 1.5, 2: 2.5},
+		},
+		{
+			scenario:       "nested map",
+			object:         python3.MustMarshal(map[string]map[string][]int{"a": {"b": {1, 2}}}),
+			expectedResult: map[string]map[string][]int{"a": {"b": {1, 2}}},
+		},
+		{
+			scenario:       "invalid key",
+			object:         python3.NewDictFromMap(map[int]int{1: 1}).AsObject(),
+			expectedResult: map[string]int(nil),
+			expectedError:  `python3: cannot unmarshal int into Go value of type string`,
+		},
+		{
+			scenario:       "invalid value",
+			object:         python3.NewDictFromMap(map[string]string{"one": "1"}).AsObject(),
+			expectedResult: map[string]int(nil),
+			expectedError:  `python3: cannot unmarshal str into Go value of type int`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			actual := reflect.New(reflect.TypeOf(tc.expectedResult)).Interface()
+
+			err := python3.Unmarshal(tc.object, actual)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+
+			actual = reflect.Indirect(reflect.ValueOf(actual)).Interface()
+
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestUnmarshal_MapToAny(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario       string
+		object         *python3.Object
+		expectedResult any
+		expectedError  string
+	}{
+		{
+			scenario:       "string keys",
+			object:         python3.MustMarshal(map[string]any{"a": 1, "b": []string{"c"}, "d": map[string]bool{"e": true}}),
+			expectedResult: map[string]any{"a": int64(1), "b": []any{"c"}, "d": map[string]any{"e": true}},
+		},
+		{
+			scenario:       "mixed keys",
+			object:         python3.MustMarshal(map[any]any{"a": 1, 2: "b"}),
+			expectedResult: map[any]any{"a": int64(1), int64(2): "b"},
+		},
+		{
+			scenario:      "unhashable keys",
+			object:        python3.MustImportModule("ast").CallMethodArgs("literal_eval", `{(1, 2): 3}`),
+			expectedError: `python3: cannot unmarshal unhashable key tuple into Go value of type map[interface {}]interface {}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			var actual any
+
+			err := python3.Unmarshal(tc.object, &actual)
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+
+			assert.Equal(t, tc.expectedResult, actual)
+		})
+	}
+}
+
+func TestUnmarshal_SliceToAny(t *testing.T) {
+	withGIL(t)
+
+	var actual any
+
+	err := python3.Unmarshal(python3.NewListFromValues(1, 2).AsObject(), &actual)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{int64(1), int64(2)}, actual)
+}
+
+func TestUnmarshal_BytesToAny(t *testing.T) {
+	withGIL(t)
+
+	var actual any
+
+	err := python3.Unmarshal(python3.NewBytes([]byte{0, 1, 255}), &actual)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0, 1, 255}, actual)
+}
+
+type (
+	flag   bool
+	label  string
+	status int
+	level  uint8
+	ratio  float32
+)
+
 type integer int //nolint: recvcheck
 
 func (i integer) MarshalPyObject() *python3.Object {
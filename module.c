@@ -0,0 +1,57 @@
+#include "Python.h"
+#include "_cgo_export.h"
+
+// MODULE_INITTAB_SIZE must match maxInittabModules in module.go.
+#define MODULE_INITTAB_SIZE 16
+
+#define MODULE_INIT(i) \
+	static int execModule##i(PyObject *module) { return initGoModule(i, module); } \
+	static PyObject *initModule##i(void) { return initModule(i, execModule##i); }
+
+static PyModuleDef moduleDefs[MODULE_INITTAB_SIZE];
+static PyModuleDef_Slot moduleSlots[MODULE_INITTAB_SIZE][3];
+
+// initModule returns the definition of the module of the slot. The module uses the multi-phase initialization, so that
+// every interpreter, including the sub-interpreters with their own GIL, creates its own module and lets Go populate it
+// with exec.
+static PyObject *initModule(int index, int (*exec)(PyObject *)) {
+	moduleSlots[index][0] = (PyModuleDef_Slot){Py_mod_exec, exec};
+	moduleSlots[index][1] = (PyModuleDef_Slot){Py_mod_multiple_interpreters, Py_MOD_PER_INTERPRETER_GIL_SUPPORTED};
+	moduleSlots[index][2] = (PyModuleDef_Slot){0, NULL};
+
+	moduleDefs[index].m_slots = moduleSlots[index];
+
+	return PyModuleDef_Init(&moduleDefs[index]);
+}
+
+MODULE_INIT(0)
+MODULE_INIT(1)
+MODULE_INIT(2)
+MODULE_INIT(3)
+MODULE_INIT(4)
+MODULE_INIT(5)
+MODULE_INIT(6)
+MODULE_INIT(7)
+MODULE_INIT(8)
+MODULE_INIT(9)
+MODULE_INIT(10)
+MODULE_INIT(11)
+MODULE_INIT(12)
+MODULE_INIT(13)
+MODULE_INIT(14)
+MODULE_INIT(15)
+
+// The init functions of the inittab take no arguments, so each module registered before initialization gets its own
+// trampoline that knows its slot.
+static PyObject *(*moduleInits[MODULE_INITTAB_SIZE])(void) = {
+	initModule0, initModule1, initModule2, initModule3,
+	initModule4, initModule5, initModule6, initModule7,
+	initModule8, initModule9, initModule10, initModule11,
+	initModule12, initModule13, initModule14, initModule15,
+};
+
+int appendModuleInittab(const char *name, int index) {
+	moduleDefs[index] = (PyModuleDef){PyModuleDef_HEAD_INIT, .m_name = name, .m_size = 0};
+
+	return PyImport_AppendInittab(name, moduleInits[index]);
+}
@@ -0,0 +1,209 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+
+int appendModuleInittab(const char *name, int index);
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// maxInittabModules is the number of modules that can be registered before the initialization. It must match
+// MODULE_INITTAB_SIZE in module.c.
+const maxInittabModules = 16
+
+// ErrTooManyModules indicates that no more modules can be registered before the initialization.
+var ErrTooManyModules = errors.New("too many modules registered before initialization")
+
+var (
+	inittabModules   []*Module
+	inittabModulesMu sync.Mutex
+)
+
+// Module is a Python module implemented in Go.
+//
+//	err := python3.NewModule("gohelpers").
+//		Func("hash", hash).
+//		Const("VERSION", "1.2").
+//		Register()
+type Module struct {
+	name    string
+	members []moduleMember
+	err     error
+}
+
+// moduleMember is a function or a constant of a module.
+type moduleMember struct {
+	name  string
+	value func(module string) (*Object, error)
+}
+
+// NewModule creates a Python module implemented in Go. The module is available to Python once it is registered.
+func NewModule(name string) *Module {
+	return &Module{name: name}
+}
+
+// Func adds a function to the module. The function is converted to a Python callable like NewFunction does.
+func (m *Module) Func(name string, fn any) *Module {
+	if rv := reflect.ValueOf(fn); rv.Kind() != reflect.Func || rv.IsNil() {
+		m.setError(fmt.Errorf("%w: %s.%s: %T", ErrNotFunction, m.name, name, fn))
+
+		return m
+	}
+
+	m.members = append(m.members, moduleMember{
+		name: name,
+		value: func(module string) (*Object, error) {
+			f, err := NewFunction(name, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := f.TrySetAttr("__module__", module); err != nil {
+				f.DecRef()
+
+				return nil, err
+			}
+
+			return f, nil
+		},
+	})
+
+	return m
+}
+
+// Const adds a constant to the module. The value is converted using Marshal when the module is created.
+func (m *Module) Const(name string, value any) *Module {
+	m.members = append(m.members, moduleMember{
+		name: name,
+		value: func(string) (*Object, error) {
+			return marshalNewRef(value)
+		},
+	})
+
+	return m
+}
+
+// Register makes the module importable.
+//
+// Before the initialization, the module is added to the table of built-in modules with PyImport_AppendInittab, and
+// every interpreter, including the ones created by NewInterpreter, creates its own module when it is imported for the
+// first time. Since the sub-interpreters have their own GIL, the functions of the module may run in parallel. At most
+// 16 modules can be registered this way. After the initialization, the module is created right away and added to
+// sys.modules and to the ImportModule cache of the current interpreter only.
+func (m *Module) Register() error {
+	if m.err != nil {
+		return m.err
+	}
+
+	if !IsInitialized() {
+		return m.appendInittab()
+	}
+
+	return m.inject()
+}
+
+// setError keeps the first error that occurs while building the module.
+func (m *Module) setError(err error) {
+	if m.err == nil {
+		m.err = err
+	}
+}
+
+// appendInittab adds the module to the table of built-in modules.
+func (m *Module) appendInittab() error {
+	inittabModulesMu.Lock()
+	defer inittabModulesMu.Unlock()
+
+	index := len(inittabModules)
+	if index >= maxInittabModules {
+		return fmt.Errorf("%w: %s", ErrTooManyModules, m.name)
+	}
+
+	// The name is not freed because the inittab keeps using it.
+	if C.appendModuleInittab(C.CString(m.name), C.int(index)) != 0 {
+		return fmt.Errorf("could not register module %s", m.name) //nolint: err113
+	}
+
+	inittabModules = append(inittabModules, m)
+
+	return nil
+}
+
+// inject creates the module and adds it to sys.modules and to the ImportModule cache.
+func (m *Module) inject() error {
+	module := NewObject(cpy3.PyModule_New(m.name))
+	if module == nil {
+		return LastError()
+	}
+
+	if err := m.populate(module); err != nil {
+		module.DecRef()
+
+		return err
+	}
+
+	if err := NewObject(cpy3.PyImport_GetModuleDict()).TrySetItem(m.name, module); err != nil {
+		module.DecRef()
+
+		return err
+	}
+
+	interp := currentInterpreter()
+	interp.modules.Delete(m.name)
+
+	_, _ = interp.modules.Do(m.name, func() (*Object, error) { //nolint: errcheck
+		interp.registerFinalizer(func() {
+			module.DecRef()
+			interp.modules.Delete(m.name)
+		})
+
+		return module, nil
+	})
+
+	return nil
+}
+
+// populate adds the functions and the constants to the module.
+func (m *Module) populate(module *Object) error {
+	for _, member := range m.members {
+		v, err := member.value(m.name)
+		if err != nil {
+			return err
+		}
+
+		err = module.TrySetAttr(member.name, v)
+
+		v.DecRef()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//export initGoModule
+func initGoModule(index C.int, module *C.PyObject) C.int {
+	inittabModulesMu.Lock()
+	m := inittabModules[index]
+	inittabModulesMu.Unlock()
+
+	if err := m.populate(fromC(module)); err != nil {
+		SetError(err)
+
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,119 @@
+package python_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestModule_Inittab(t *testing.T) {
+	withGIL(t)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	err := python3.Exec("import sys, gotest\nbuiltin = 'gotest' in sys.builtin_module_names\nresult = gotest.add(1, 2)\n", globals, nil)
+	require.NoError(t, err)
+
+	assert.True(t, python3.AsBool(globals.Get("builtin")))
+	assert.Equal(t, 3, python3.AsInt(globals.Get("result")))
+
+	module := python3.MustImportModule("gotest")
+
+	assert.Equal(t, "1.2", python3.MustUnmarshalAs[string](module.GetAttr("VERSION")))
+}
+
+func TestModule_Inittab_Interpreter(t *testing.T) {
+	withGIL(t)
+
+	add := python3.MustImportModule("gotest").GetAttr("add")
+	defer add.DecRef()
+
+	interp := newInterpreter(t)
+
+	err := interp.Run(func() error {
+		globals := python3.NewDictObject()
+		defer globals.DecRef()
+
+		err := python3.Exec("import gotest\nresult = (gotest.add(1, 2), gotest.VERSION)\n", globals, nil)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, "(3, '1.2')", globals.Get("result").String())
+
+		// The module is created again in the interpreter instead of sharing the objects of the main interpreter.
+		subAdd := python3.MustImportModule("gotest").GetAttr("add")
+		defer subAdd.DecRef()
+
+		assert.NotSame(t, add.PyObject(), subAdd.PyObject())
+
+		return nil
+	})
+
+	require.NoError(t, err)
+}
+
+func TestModule_Register(t *testing.T) {
+	withGIL(t)
+
+	err := python3.NewModule("gohelpers").
+		Func("upper", strings.ToUpper).
+		Func("split", func(s, sep string) []string { return strings.Split(s, sep) }).
+		Const("VERSION", "1.2").
+		Const("LIMITS", map[string]int{"max": 10}).
+		Register()
+	require.NoError(t, err)
+
+	globals := python3.NewDictObject()
+	defer globals.DecRef()
+
+	code := "import gohelpers\n" +
+		"result = (gohelpers.upper('go'), gohelpers.split('a,b', ','), gohelpers.VERSION, gohelpers.LIMITS['max'])\n" +
+		"module = gohelpers.upper.__module__\n"
+
+	err = python3.Exec(code, globals, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "('GO', ['a', 'b'], '1.2', 10)", globals.Get("result").String())
+	assert.Equal(t, "gohelpers", python3.MustUnmarshalAs[string](globals.Get("module")))
+
+	module := python3.MustImportModule("gohelpers")
+
+	assert.True(t, module.Equal(python3.MustImportModule("sys").GetAttr("modules").GetItem("gohelpers")))
+}
+
+func TestModule_Register_Replace(t *testing.T) {
+	withGIL(t)
+
+	err := python3.NewModule("goreplaced").Const("VERSION", 1).Register()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, python3.AsInt(python3.MustImportModule("goreplaced").GetAttr("VERSION")))
+
+	err = python3.NewModule("goreplaced").Const("VERSION", 2).Register()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, python3.AsInt(python3.MustImportModule("goreplaced").GetAttr("VERSION")))
+}
+
+func TestModule_Register_NotFunction(t *testing.T) {
+	withGIL(t)
+
+	err := python3.NewModule("gobroken").
+		Func("value", 42).
+		Register()
+
+	require.ErrorIs(t, err, python3.ErrNotFunction)
+	assert.EqualError(t, err, "value is not a function: gobroken.value: int")
+
+	_, err = python3.ImportModule("gobroken")
+
+	var importErr python3.ModuleNotFoundError
+
+	require.ErrorAs(t, err, &importErr)
+}
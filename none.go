@@ -0,0 +1,18 @@
+package python
+
+import cpy3 "go.nhat.io/cpy/v3"
+
+// None is a wrapper of cpy3.Py_None.
+var None = NewObject(cpy3.Py_None)
+
+// IsNone returns true if the object is None.
+func IsNone(o PyObjector) bool {
+	return o.PyObject() == cpy3.Py_None
+}
+
+// newNone returns a new reference to None.
+func newNone() *Object {
+	cpy3.Py_None.IncRef()
+
+	return None
+}
@@ -0,0 +1,133 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestIsNone(t *testing.T) {
+	withGIL(t)
+
+	assert.True(t, python3.IsNone(python3.None))
+	assert.False(t, python3.IsNone(python3.False))
+	assert.False(t, python3.IsNone((*python3.Object)(nil)))
+	assert.Equal(t, "None", python3.None.String())
+}
+
+func TestMarshal_Nil(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		value    any
+	}{
+		{
+			scenario: "nil",
+			value:    nil,
+		},
+		{
+			scenario: "nil pointer",
+			value:    (*int)(nil),
+		},
+		{
+			scenario: "nil object",
+			value:    (*python3.Object)(nil),
+		},
+		{
+			scenario: "nil slice",
+			value:    []int(nil),
+		},
+		{
+			scenario: "nil bytes",
+			value:    []byte(nil),
+		},
+		{
+			scenario: "nil map",
+			value:    map[string]int(nil),
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			actual, err := python3.Marshal(tc.value)
+			require.NoError(t, err)
+
+			assert.True(t, python3.IsNone(actual))
+		})
+	}
+}
+
+func TestMarshal_NilItems(t *testing.T) {
+	withGIL(t)
+
+	type item struct {
+		Name  *string        `python:"name"`
+		Tags  []string       `python:"tags"`
+		Attrs map[string]any `python:"attrs"`
+	}
+
+	o, err := python3.Marshal([]any{1, nil, item{}})
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.Equal(t, "[1, None, {'name': None, 'tags': None, 'attrs': None}]", o.String())
+}
+
+func TestUnmarshal_None(t *testing.T) {
+	withGIL(t)
+
+	run(t, "pointer", func(t *testing.T) {
+		v := new(int)
+
+		require.NoError(t, python3.Unmarshal(python3.None, &v))
+		assert.Nil(t, v)
+	})
+
+	run(t, "interface", func(t *testing.T) {
+		var v any = 42
+
+		require.NoError(t, python3.Unmarshal(python3.None, &v))
+		assert.Nil(t, v)
+	})
+
+	run(t, "slice", func(t *testing.T) {
+		v := []int{1}
+
+		require.NoError(t, python3.Unmarshal(python3.None, &v))
+		assert.Nil(t, v)
+	})
+
+	run(t, "map", func(t *testing.T) {
+		v := map[string]int{"a": 1}
+
+		require.NoError(t, python3.Unmarshal(python3.None, &v))
+		assert.Nil(t, v)
+	})
+
+	run(t, "in list", func(t *testing.T) {
+		o, err := python3.Eval(`[1, None]`, nil, nil)
+		require.NoError(t, err)
+
+		defer o.DecRef()
+
+		v, err := python3.UnmarshalAs[[]*int](o)
+		require.NoError(t, err)
+
+		require.Len(t, v, 2)
+		assert.Equal(t, 1, *v[0])
+		assert.Nil(t, v[1])
+	})
+
+	run(t, "int", func(t *testing.T) {
+		var v int
+
+		err := python3.Unmarshal(python3.None, &v)
+
+		assert.EqualError(t, err, "python3: cannot unmarshal NoneType into Go value of type int")
+	})
+}
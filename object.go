@@ -46,26 +46,90 @@ func (o *Object) Length() int {
 // CallMethodArgs calls a method of the object.
 func (o *Object) CallMethodArgs(name string, args ...any) *Object {
 	oArgs := make([]*cpy3.PyObject, len(args))
+
 	for i, arg := range args {
-		oArgs[i] = toPyObject(arg)
+		pyArg, release := borrowPyObject(arg)
+		defer release()
+
+		oArgs[i] = pyArg
 	}
 
 	return NewObject((*cpy3.PyObject)(o).CallMethodArgs(name, oArgs...))
 }
 
+// Call calls the object with positional arguments, like calling a function or a class in Python. The arguments are
+// converted using Marshal.
+func (o *Object) Call(args ...any) (*Object, error) {
+	return o.CallKw(args, nil)
+}
+
+// CallKw calls the object with positional and keyword arguments. The arguments are converted using Marshal. If the call
+// raises a Python exception, the exception is returned as an error.
+func (o *Object) CallKw(args []any, kwargs map[string]any) (*Object, error) {
+	pyArgs, err := marshalTuple(args)
+	if err != nil {
+		return nil, err
+	}
+
+	defer pyArgs.DecRef()
+
+	var pyKwargs *Object
+
+	if len(kwargs) > 0 {
+		if pyKwargs, err = Marshal(kwargs); err != nil {
+			return nil, err
+		}
+
+		defer pyKwargs.DecRef()
+	}
+
+	result := (*cpy3.PyObject)(o).Call(pyArgs.PyObject(), pyKwargs.PyObject())
+	if result == nil {
+		return nil, LastError()
+	}
+
+	return NewObject(result), nil
+}
+
+// CallMethodKw calls a method of the object with positional and keyword arguments. The arguments are converted using
+// Marshal. If the object does not have the method or the call raises a Python exception, the exception is returned as
+// an error.
+func (o *Object) CallMethodKw(name string, args []any, kwargs map[string]any) (*Object, error) {
+	method, err := o.TryGetAttr(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer method.DecRef()
+
+	return method.CallKw(args, kwargs)
+}
+
 // GetItem returns the item of the object.
 func (o *Object) GetItem(key any) *Object {
-	return NewObject((*cpy3.PyObject)(o).GetItem(toPyObject(key)))
+	pyKey, release := borrowPyObject(key)
+	defer release()
+
+	return NewObject((*cpy3.PyObject)(o).GetItem(pyKey))
 }
 
 // SetItem returns the item of the object.
 func (o *Object) SetItem(key, value any) {
-	(*cpy3.PyObject)(o).SetItem(toPyObject(key), toPyObject(value))
+	pyKey, releaseKey := borrowPyObject(key)
+	defer releaseKey()
+
+	pyValue, releaseValue := borrowPyObject(value)
+	defer releaseValue()
+
+	(*cpy3.PyObject)(o).SetItem(pyKey, pyValue)
 }
 
 // HasItem returns true if the object has the item.
 func (o *Object) HasItem(value any) bool {
-	return cpy3.PySequence_Contains((*cpy3.PyObject)(o), toPyObject(value)) == 1
+	pyValue, release := borrowPyObject(value)
+	defer release()
+
+	return cpy3.PySequence_Contains((*cpy3.PyObject)(o), pyValue) == 1
 }
 
 // GetAttr returns the attribute value of the object.
@@ -75,7 +139,113 @@ func (o *Object) GetAttr(name string) *Object {
 
 // SetAttr sets the attribute value of the object.
 func (o *Object) SetAttr(name string, value any) {
-	(*cpy3.PyObject)(o).SetAttrString(name, toPyObject(value))
+	pyValue, release := borrowPyObject(value)
+	defer release()
+
+	(*cpy3.PyObject)(o).SetAttrString(name, pyValue)
+}
+
+// TryLength returns the length of the object, or an error if the object has no length.
+func (o *Object) TryLength() (int, error) {
+	length := (*cpy3.PyObject)(o).Length()
+	if length < 0 {
+		return 0, LastError()
+	}
+
+	return length, nil
+}
+
+// TryCallMethod calls a method of the object with positional arguments. Unlike CallMethodArgs, it returns an error
+// instead of nil if the arguments cannot be converted or the call fails.
+func (o *Object) TryCallMethod(name string, args ...any) (*Object, error) {
+	return o.CallMethodKw(name, args, nil)
+}
+
+// TryGetItem returns the item of the object. Unlike GetItem, it returns an error, such as IndexError, instead of nil if
+// the item cannot be retrieved.
+func (o *Object) TryGetItem(key any) (*Object, error) {
+	pyKey, err := marshalNewRef(key)
+	if err != nil {
+		return nil, err
+	}
+
+	defer pyKey.DecRef()
+
+	item := (*cpy3.PyObject)(o).GetItem(pyKey.PyObject())
+	if item == nil {
+		return nil, LastError()
+	}
+
+	return NewObject(item), nil
+}
+
+// TrySetItem sets the item of the object. Unlike SetItem, it returns an error if the item cannot be set.
+func (o *Object) TrySetItem(key, value any) error {
+	pyKey, err := marshalNewRef(key)
+	if err != nil {
+		return err
+	}
+
+	defer pyKey.DecRef()
+
+	pyValue, err := marshalNewRef(value)
+	if err != nil {
+		return err
+	}
+
+	defer pyValue.DecRef()
+
+	if (*cpy3.PyObject)(o).SetItem(pyKey.PyObject(), pyValue.PyObject()) < 0 {
+		return LastError()
+	}
+
+	return nil
+}
+
+// TryHasItem returns true if the object has the item. Unlike HasItem, it returns an error if the object is not a
+// container or the value cannot be converted.
+func (o *Object) TryHasItem(value any) (bool, error) {
+	pyValue, err := marshalNewRef(value)
+	if err != nil {
+		return false, err
+	}
+
+	defer pyValue.DecRef()
+
+	result := cpy3.PySequence_Contains((*cpy3.PyObject)(o), pyValue.PyObject())
+	if result < 0 {
+		return false, LastError()
+	}
+
+	return result == 1, nil
+}
+
+// TryGetAttr returns the attribute value of the object. Unlike GetAttr, it returns an error instead of nil if the
+// attribute cannot be retrieved.
+func (o *Object) TryGetAttr(name string) (*Object, error) {
+	attr := (*cpy3.PyObject)(o).GetAttrString(name)
+	if attr == nil {
+		return nil, LastError()
+	}
+
+	return NewObject(attr), nil
+}
+
+// TrySetAttr sets the attribute value of the object. Unlike SetAttr, it returns an error if the attribute cannot be
+// set.
+func (o *Object) TrySetAttr(name string, value any) error {
+	pyValue, err := marshalNewRef(value)
+	if err != nil {
+		return err
+	}
+
+	defer pyValue.DecRef()
+
+	if (*cpy3.PyObject)(o).SetAttrString(name, pyValue.PyObject()) < 0 {
+		return LastError()
+	}
+
+	return nil
 }
 
 // Equal returns true if the object is equal to o2.
@@ -97,7 +267,40 @@ func NewObject(obj *cpy3.PyObject) *Object {
 	return (*Object)(obj)
 }
 
-// toPyObject converts a value to a PyObject.
+// marshalTuple converts the values to a tuple.
+func marshalTuple(values []any) (*TupleObject, error) {
+	tuple := NewTupleObject(len(values))
+
+	for i, v := range values {
+		o, err := marshalNewRef(v)
+		if err != nil {
+			tuple.DecRef()
+
+			return nil, err
+		}
+
+		cpy3.PyTuple_SetItem(tuple.PyObject(), i, o.PyObject())
+	}
+
+	return tuple, nil
+}
+
+// toPyObject converts a value to a PyObject for a function that steals the reference. A Python object is given as is.
 func toPyObject(v any) *cpy3.PyObject {
 	return MustMarshal(v).PyObject()
 }
+
+// borrowPyObject converts a value to a PyObject for a function that does not steal the reference. The release function
+// releases the object if it was created for the value.
+func borrowPyObject(v any) (*cpy3.PyObject, func()) {
+	o := MustMarshal(v)
+
+	switch v.(type) {
+	case *cpy3.PyObject, *Object, Objector, PyObjector:
+		if !isNil(v) {
+			return o.PyObject(), func() {}
+		}
+	}
+
+	return o.PyObject(), o.DecRef
+}
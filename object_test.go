@@ -0,0 +1,277 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func TestObject_Call(t *testing.T) {
+	withGIL(t)
+
+	sqrt := python3.MustImportModule("math").GetAttr("sqrt")
+	defer sqrt.DecRef()
+
+	result, err := sqrt.Call(16)
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.InDelta(t, 4.0, python3.AsFloat64(result), 0.0001)
+}
+
+func TestObject_Call_Class(t *testing.T) {
+	withGIL(t)
+
+	fraction := python3.MustImportModule("fractions").GetAttr("Fraction")
+	defer fraction.DecRef()
+
+	result, err := fraction.Call(3, 6)
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, "1/2", result.String())
+}
+
+func TestObject_Call_ObjectArgument(t *testing.T) {
+	withGIL(t)
+
+	length := python3.MustImportModule("builtins").GetAttr("len")
+	defer length.DecRef()
+
+	list := python3.NewListFromValues(1, 2, 3)
+	defer list.DecRef()
+
+	for range 3 {
+		result, err := length.Call(list)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, python3.AsInt(result))
+	}
+
+	assert.Equal(t, `[1, 2, 3]`, list.String())
+}
+
+func TestObject_Call_Error(t *testing.T) {
+	withGIL(t)
+
+	sqrt := python3.MustImportModule("math").GetAttr("sqrt")
+	defer sqrt.DecRef()
+
+	result, err := sqrt.Call("four")
+
+	require.EqualError(t, err, `must be real number, not str`)
+	assert.Nil(t, result)
+
+	result, err = sqrt.Call(make(chan struct{}))
+
+	require.EqualError(t, err, `cannot marshal value of chan struct {} to python object`)
+	assert.Nil(t, result)
+}
+
+func TestObject_CallKw(t *testing.T) {
+	withGIL(t)
+
+	sorted := python3.MustImportModule("builtins").GetAttr("sorted")
+	defer sorted.DecRef()
+
+	result, err := sorted.CallKw([]any{[]int{3, 1, 2}}, map[string]any{"reverse": true})
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, []int{3, 2, 1}, python3.MustUnmarshalAs[[]int](result))
+}
+
+func TestObject_CallKw_Error(t *testing.T) {
+	withGIL(t)
+
+	sorted := python3.MustImportModule("builtins").GetAttr("sorted")
+	defer sorted.DecRef()
+
+	result, err := sorted.CallKw([]any{[]int{3, 1, 2}}, map[string]any{"unknown": true})
+
+	require.EqualError(t, err, `'unknown' is an invalid keyword argument for sort()`)
+	assert.Nil(t, result)
+
+	result, err = sorted.CallKw([]any{[]int{3, 1, 2}}, map[string]any{"key": make(chan struct{})})
+
+	require.EqualError(t, err, `cannot marshal value of chan struct {} to python object`)
+	assert.Nil(t, result)
+}
+
+func TestObject_CallMethodKw(t *testing.T) {
+	withGIL(t)
+
+	format := python3.NewString("{greeting}, {0}!")
+	defer format.DecRef()
+
+	result, err := format.CallMethodKw("format", []any{"gopher"}, map[string]any{"greeting": "Hello"})
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.Equal(t, "Hello, gopher!", result.String())
+}
+
+func TestObject_CallMethodKw_Error(t *testing.T) {
+	withGIL(t)
+
+	json := python3.MustImportModule("json")
+
+	result, err := json.CallMethodKw("loads", []any{"{"}, nil)
+
+	require.EqualError(t, err, `Expecting property name enclosed in double quotes: line 1 column 2 (char 1)`)
+	assert.Nil(t, result)
+
+	result, err = json.CallMethodKw("unknown", nil, nil)
+
+	require.EqualError(t, err, `module 'json' has no attribute 'unknown'`)
+	assert.Nil(t, result)
+}
+
+func TestObject_TryGetAttr(t *testing.T) {
+	withGIL(t)
+
+	sys := python3.MustImportModule("sys")
+
+	platform, err := sys.TryGetAttr("platform")
+	require.NoError(t, err)
+
+	defer platform.DecRef()
+
+	assert.True(t, python3.IsString(platform))
+
+	unknown, err := sys.TryGetAttr("unknown")
+
+	require.EqualError(t, err, `module 'sys' has no attribute 'unknown'`)
+	assert.Nil(t, unknown)
+}
+
+func TestObject_TrySetAttr(t *testing.T) {
+	withGIL(t)
+
+	namespace, err := python3.MustImportModule("types").CallMethodKw("SimpleNamespace", nil, nil)
+	require.NoError(t, err)
+
+	defer namespace.DecRef()
+
+	err = namespace.TrySetAttr("name", "gopher")
+	require.NoError(t, err)
+
+	assert.Equal(t, `namespace(name='gopher')`, namespace.String())
+
+	err = namespace.TrySetAttr("name", make(chan struct{}))
+
+	require.EqualError(t, err, `cannot marshal value of chan struct {} to python object`)
+
+	err = python3.NewInt(42).TrySetAttr("name", "gopher")
+
+	require.EqualError(t, err, `'int' object has no attribute 'name'`)
+}
+
+func TestObject_TryGetItem(t *testing.T) {
+	withGIL(t)
+
+	list := python3.NewListFromValues(1, 2, 3).AsObject()
+	defer list.DecRef()
+
+	item, err := list.TryGetItem(1)
+	require.NoError(t, err)
+
+	defer item.DecRef()
+
+	assert.Equal(t, 2, python3.AsInt(item))
+
+	item, err = list.TryGetItem(3)
+
+	expected := python3.IndexError{LookupError: python3.LookupError{Exception: python3.Exception{Message: `list index out of range`, Type: "IndexError"}}}
+
+	require.Equal(t, expected, comparableError(err))
+	assert.Nil(t, item)
+
+	item, err = list.TryGetItem(make(chan struct{}))
+
+	require.EqualError(t, err, `cannot marshal value of chan struct {} to python object`)
+	assert.Nil(t, item)
+}
+
+func TestObject_TrySetItem(t *testing.T) {
+	withGIL(t)
+
+	dict := python3.NewDict().AsObject()
+	defer dict.DecRef()
+
+	err := dict.TrySetItem("numbers", []int{1, 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, `{'numbers': [1, 2]}`, dict.String())
+
+	err = dict.TrySetItem([]int{1}, 1)
+
+	require.EqualError(t, err, `unhashable type: 'list'`)
+
+	err = dict.TrySetItem("key", make(chan struct{}))
+
+	require.EqualError(t, err, `cannot marshal value of chan struct {} to python object`)
+
+	err = python3.NewTuple(1).AsObject().TrySetItem(0, 1)
+
+	require.EqualError(t, err, `'tuple' object does not support item assignment`)
+}
+
+func TestObject_TryHasItem(t *testing.T) {
+	withGIL(t)
+
+	list := python3.NewListFromValues(1, 2, 3).AsObject()
+	defer list.DecRef()
+
+	ok, err := list.TryHasItem(2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = list.TryHasItem(4)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = python3.NewInt(42).TryHasItem(4)
+
+	require.EqualError(t, err, `argument of type 'int' is not iterable`)
+	assert.False(t, ok)
+}
+
+func TestObject_TryLength(t *testing.T) {
+	withGIL(t)
+
+	length, err := python3.NewString("hello").TryLength()
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, length)
+
+	length, err = python3.NewInt(42).TryLength()
+
+	require.EqualError(t, err, `object of type 'int' has no len()`)
+	assert.Equal(t, 0, length)
+}
+
+func TestObject_TryCallMethod(t *testing.T) {
+	withGIL(t)
+
+	math := python3.MustImportModule("math")
+
+	result, err := math.TryCallMethod("pow", 2, 10)
+	require.NoError(t, err)
+
+	defer result.DecRef()
+
+	assert.InDelta(t, 1024.0, python3.AsFloat64(result), 0.0001)
+
+	result, err = math.TryCallMethod("pow", 2)
+
+	require.EqualError(t, err, `pow expected 2 arguments, got 1`)
+	assert.Nil(t, result)
+}
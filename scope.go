@@ -0,0 +1,57 @@
+package python
+
+// Scope tracks Python objects and releases them all at once when it is closed, instead of a chain of defer DecRef
+// calls. A Scope must be closed by the thread that holds the GIL, and it is not safe for concurrent use.
+//
+//	err := python3.WithScope(func(s *python3.Scope) error {
+//		values := s.Track(python3.MustMarshal([]int{1, 2, 3}))
+//		total := s.Track(python3.MustImportModule("builtins").CallMethodArgs("sum", values))
+//
+//		fmt.Println(total)
+//
+//		return nil
+//	})
+type Scope struct {
+	objects []PyObjector
+}
+
+// NewScope creates an empty scope. Call Close to release the tracked objects.
+func NewScope() *Scope {
+	return &Scope{}
+}
+
+// WithScope calls fn with a new scope, and releases the objects tracked by the scope when fn returns or panics. An
+// object that must outlive the scope needs an extra reference, or a Managed handle, see Manage.
+func WithScope(fn func(s *Scope) error) error {
+	s := NewScope()
+	defer s.Close()
+
+	return fn(s)
+}
+
+// Track tracks the object and returns it, so that it can be used in an expression. A nil object is ignored.
+func (s *Scope) Track(o *Object) *Object {
+	s.Add(o)
+
+	return o
+}
+
+// Add tracks the objects, such as a *TupleObject or a *List[T]. The nil objects are ignored.
+func (s *Scope) Add(objects ...PyObjector) {
+	for _, o := range objects {
+		if isNil(o) || o.PyObject() == nil {
+			continue
+		}
+
+		s.objects = append(s.objects, o)
+	}
+}
+
+// Close releases the tracked objects in the reverse order of tracking. The scope can be reused afterward.
+func (s *Scope) Close() {
+	for i := len(s.objects) - 1; i >= 0; i-- {
+		s.objects[i].PyObject().DecRef()
+	}
+
+	s.objects = nil
+}
@@ -0,0 +1,129 @@
+package python_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+// refCount returns the reference count of the object, as seen by sys.getrefcount.
+func refCount(t *testing.T, o python3.PyObjector) int {
+	t.Helper()
+
+	count := python3.MustImportModule("sys").CallMethodArgs("getrefcount", o)
+	defer count.DecRef()
+
+	return python3.AsInt(count)
+}
+
+func TestWithScope(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewListFromValues(1, 2, 3)
+	defer o.DecRef()
+
+	before := refCount(t, o)
+
+	err := python3.WithScope(func(s *python3.Scope) error {
+		o.PyObject().IncRef()
+		o.PyObject().IncRef()
+
+		s.Track(o.AsObject())
+		s.Add(o, nil, (*python3.Object)(nil))
+
+		assert.Equal(t, before+2, refCount(t, o))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, before, refCount(t, o))
+}
+
+func TestWithScope_Error(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewString("hello")
+	defer o.DecRef()
+
+	before := refCount(t, o)
+	expected := errors.New("failed")
+
+	err := python3.WithScope(func(s *python3.Scope) error {
+		o.PyObject().IncRef()
+
+		s.Track(o)
+
+		return expected
+	})
+
+	require.ErrorIs(t, err, expected)
+	assert.Equal(t, before, refCount(t, o))
+}
+
+func TestWithScope_Panic(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewString("hello")
+	defer o.DecRef()
+
+	before := refCount(t, o)
+
+	assert.Panics(t, func() {
+		_ = python3.WithScope(func(s *python3.Scope) error { //nolint: errcheck
+			o.PyObject().IncRef()
+
+			s.Track(o)
+
+			panic("failed")
+		})
+	})
+
+	assert.Equal(t, before, refCount(t, o))
+}
+
+func TestScope_Close(t *testing.T) {
+	withGIL(t)
+
+	o := python3.NewString("hello")
+	defer o.DecRef()
+
+	before := refCount(t, o)
+
+	s := python3.NewScope()
+
+	o.PyObject().IncRef()
+	s.Track(o)
+
+	s.Close()
+	s.Close()
+
+	assert.Equal(t, before, refCount(t, o))
+}
+
+func TestObject_CallMethodArgs_References(t *testing.T) {
+	withGIL(t)
+
+	item := python3.NewString("hello")
+	defer item.DecRef()
+
+	l := python3.NewList(0)
+	defer l.DecRef()
+
+	before := refCount(t, item)
+
+	python3.WithScope(func(s *python3.Scope) error { //nolint: errcheck
+		s.Track(l.AsObject().CallMethodArgs("append", item))
+		s.Track(l.AsObject().CallMethodArgs("append", "world"))
+
+		return nil
+	})
+
+	// The list holds a reference to the item, and the call does not keep or release another one.
+	assert.Equal(t, before+1, refCount(t, item))
+	assert.Equal(t, "['hello', 'world']", l.String())
+}
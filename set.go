@@ -0,0 +1,275 @@
+package python
+
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+
+static int isAnySet(PyObject *o) {
+	return PyAnySet_Check(o);
+}
+
+static int isFrozenSet(PyObject *o) {
+	return PyFrozenSet_Check(o);
+}
+*/
+import "C"
+
+import (
+	"reflect"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+var emptyStructType = reflect.TypeFor[struct{}]()
+
+// IsSet returns true if the object is a set or a frozenset.
+func IsSet(o PyObjector) bool {
+	return C.isAnySet(toC(o)) != 0
+}
+
+// IsFrozenSet returns true if the object is a frozenset.
+func IsFrozenSet(o PyObjector) bool {
+	return C.isFrozenSet(toC(o)) != 0
+}
+
+// SetObject is a generic Python set or frozenset.
+type SetObject cpy3.PyObject
+
+// DecRef decreases the reference count of the object.
+func (o *SetObject) DecRef() {
+	if o == nil {
+		return
+	}
+
+	(*cpy3.PyObject)(o).DecRef()
+}
+
+// PyObject returns the underlying PyObject.
+func (o *SetObject) PyObject() *cpy3.PyObject {
+	return (*cpy3.PyObject)(o)
+}
+
+// Length returns the number of items in the set.
+func (o *SetObject) Length() int {
+	return int(C.PySet_Size(toC(o)))
+}
+
+// Add adds value to the set. It panics if the value is not hashable, or if the set is a frozenset.
+func (o *SetObject) Add(value any) {
+	defer MustSuccess()
+
+	// PySet_Add also fills a new frozenset, which must stay immutable once it is shared.
+	if IsFrozenSet(o) {
+		cpy3.PyErr_SetString(cpy3.PyExc_AttributeError, "'frozenset' object has no attribute 'add'")
+
+		return
+	}
+
+	pyValue, release := borrowPyObject(value)
+	defer release()
+
+	C.PySet_Add(toC(o), toC(NewObject(pyValue)))
+}
+
+// add adds the item to the set, and returns the error if the item is not hashable.
+func (o *SetObject) add(item *Object) error {
+	if C.PySet_Add(toC(o), toC(item)) != 0 {
+		return LastError()
+	}
+
+	return nil
+}
+
+// Discard removes value from the set. It does nothing if the set does not contain the value.
+func (o *SetObject) Discard(value any) {
+	defer MustSuccess()
+
+	pyValue, release := borrowPyObject(value)
+	defer release()
+
+	C.PySet_Discard(toC(o), toC(NewObject(pyValue)))
+}
+
+// Contains returns true if the set contains value.
+func (o *SetObject) Contains(value any) bool {
+	defer MustSuccess()
+
+	pyValue, release := borrowPyObject(value)
+	defer release()
+
+	return C.PySet_Contains(toC(o), toC(NewObject(pyValue))) == 1
+}
+
+// Union returns a new set with the items of the set and the other set. The result is a frozenset if the set is a
+// frozenset.
+func (o *SetObject) Union(other *SetObject) *SetObject {
+	defer MustSuccess()
+
+	return (*SetObject)(fromC(C.PyNumber_Or(toC(o), toC(other))))
+}
+
+// Intersection returns a new set with the items that are in both the set and the other set. The result is a frozenset
+// if the set is a frozenset.
+func (o *SetObject) Intersection(other *SetObject) *SetObject {
+	defer MustSuccess()
+
+	return (*SetObject)(fromC(C.PyNumber_And(toC(o), toC(other))))
+}
+
+// AsObject returns the set as Object.
+func (o *SetObject) AsObject() *Object {
+	return (*Object)(o)
+}
+
+// AsFrozenSet converts the set to a new frozenset.
+func (o *SetObject) AsFrozenSet() *SetObject {
+	defer MustSuccess()
+
+	return (*SetObject)(fromC(C.PyFrozenSet_New(toC(o))))
+}
+
+// AsList converts the set to a new list, in the iteration order of the set.
+func (o *SetObject) AsList() *ListObject {
+	defer MustSuccess()
+
+	return (*ListObject)(fromC(C.PySequence_List(toC(o))))
+}
+
+// String returns the string representation of the object.
+func (o *SetObject) String() string {
+	return asString((*cpy3.PyObject)(o))
+}
+
+// forEach calls fn for every item in the set. The item is released when fn returns.
+func (o *SetObject) forEach(fn func(item *Object)) {
+	for item, err := range o.AsObject().Iter() {
+		if err != nil {
+			panic(err)
+		}
+
+		fn(item)
+	}
+}
+
+// NewSetObject creates a new empty set.
+func NewSetObject() *SetObject {
+	return (*SetObject)(fromC(C.PySet_New(nil)))
+}
+
+// Set is a generic Python set or frozenset.
+type Set[T comparable] struct {
+	obj *SetObject
+}
+
+// UnmarshalPyObject unmarshals a Python object to the set.
+func (s *Set[T]) UnmarshalPyObject(o *Object) error {
+	if !IsSet(o) {
+		return &UnmarshalTypeError{Value: TypeName(o), Type: reflect.TypeOf(s)}
+	}
+
+	s.obj = (*SetObject)(o)
+
+	return nil
+}
+
+// DecRef decreases the reference count of the object.
+func (s *Set[T]) DecRef() {
+	if s == nil {
+		return
+	}
+
+	s.obj.DecRef()
+}
+
+// PyObject returns the underlying PyObject.
+func (s *Set[T]) PyObject() *cpy3.PyObject {
+	return s.obj.PyObject()
+}
+
+// Length returns the number of items in the set.
+func (s *Set[T]) Length() int {
+	return s.obj.Length()
+}
+
+// Add adds value to the set.
+func (s *Set[T]) Add(value T) {
+	s.obj.Add(value)
+}
+
+// Discard removes value from the set. It does nothing if the set does not contain the value.
+func (s *Set[T]) Discard(value T) {
+	s.obj.Discard(value)
+}
+
+// Contains returns true if the set contains value.
+func (s *Set[T]) Contains(value T) bool {
+	return s.obj.Contains(value)
+}
+
+// Union returns a new set with the items of the set and the other set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	return &Set[T]{
+		obj: s.obj.Union(other.obj),
+	}
+}
+
+// Intersection returns a new set with the items that are in both the set and the other set.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	return &Set[T]{
+		obj: s.obj.Intersection(other.obj),
+	}
+}
+
+// AsObject returns the set as Object.
+func (s *Set[T]) AsObject() *Object {
+	return s.obj.AsObject()
+}
+
+// AsFrozenSet converts the set to a new frozenset.
+func (s *Set[T]) AsFrozenSet() *Set[T] {
+	return &Set[T]{
+		obj: s.obj.AsFrozenSet(),
+	}
+}
+
+// AsSlice converts the set to a slice, in the iteration order of the set.
+func (s *Set[T]) AsSlice() []T {
+	slice := make([]T, 0, s.Length())
+
+	s.obj.forEach(func(item *Object) {
+		slice = append(slice, MustUnmarshalAs[T](item))
+	})
+
+	return slice
+}
+
+// String returns the string representation of the object.
+func (s *Set[T]) String() string {
+	return s.obj.String()
+}
+
+// AnySet is a Python set.
+type AnySet = Set[any]
+
+// NewSet creates a new empty set.
+func NewSet() *AnySet {
+	return NewSetForType[any]()
+}
+
+// NewSetForType creates a new empty set for the given item type.
+func NewSetForType[T comparable]() *Set[T] {
+	return &Set[T]{
+		obj: NewSetObject(),
+	}
+}
+
+// NewSetFromValues converts the values to a set.
+func NewSetFromValues[T comparable](values ...T) *Set[T] {
+	set := NewSetForType[T]()
+
+	for _, v := range values {
+		set.Add(v)
+	}
+
+	return set
+}
@@ -0,0 +1,203 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+func evalSet(t *testing.T, expr string) *python3.Object {
+	t.Helper()
+
+	o, err := python3.Eval(expr, nil, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(o.DecRef)
+
+	return o
+}
+
+func TestSet_DecRefNil(t *testing.T) {
+	withGIL(t)
+
+	var set *python3.AnySet
+
+	assert.NotPanics(t, func() {
+		set.DecRef()
+	})
+}
+
+func TestSet_IsSet(t *testing.T) {
+	withGIL(t)
+
+	set := python3.NewSet()
+	defer set.DecRef()
+
+	assert.True(t, python3.IsSet(set))
+	assert.False(t, python3.IsFrozenSet(set))
+	assert.True(t, python3.IsSet(evalSet(t, `frozenset()`)))
+	assert.True(t, python3.IsFrozenSet(evalSet(t, `frozenset()`)))
+	assert.False(t, python3.IsSet(evalSet(t, `[1, 2]`)))
+	assert.False(t, python3.IsSet(evalSet(t, `{1: 2}`)))
+}
+
+func TestSet_AddDiscardContains(t *testing.T) {
+	withGIL(t)
+
+	set := python3.NewSetForType[string]()
+	defer set.DecRef()
+
+	set.Add("one")
+	set.Add("two")
+	set.Add("one")
+
+	assert.Equal(t, 2, set.Length())
+	assert.True(t, set.Contains("one"))
+	assert.False(t, set.Contains("three"))
+
+	set.Discard("one")
+	set.Discard("three")
+
+	assert.Equal(t, 1, set.Length())
+	assert.False(t, set.Contains("one"))
+	assert.Equal(t, `{'two'}`, set.String())
+}
+
+func TestSet_UnhashableItem(t *testing.T) {
+	withGIL(t)
+
+	set := python3.NewSet()
+	defer set.DecRef()
+
+	assert.Panics(t, func() {
+		set.Add([]int{1})
+	})
+}
+
+func TestSet_UnionIntersection(t *testing.T) {
+	withGIL(t)
+
+	a := python3.NewSetFromValues(1, 2, 3)
+	defer a.DecRef()
+
+	b := python3.NewSetFromValues(2, 3, 4)
+	defer b.DecRef()
+
+	union := a.Union(b)
+	defer union.DecRef()
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, union.AsSlice())
+
+	intersection := a.Intersection(b)
+	defer intersection.DecRef()
+
+	assert.ElementsMatch(t, []int{2, 3}, intersection.AsSlice())
+
+	assert.ElementsMatch(t, []int{1, 2, 3}, a.AsSlice())
+}
+
+func TestSet_AsFrozenSet(t *testing.T) {
+	withGIL(t)
+
+	set := python3.NewSetFromValues("a", "b")
+	defer set.DecRef()
+
+	frozen := set.AsFrozenSet()
+	defer frozen.DecRef()
+
+	assert.True(t, python3.IsFrozenSet(frozen))
+	assert.ElementsMatch(t, []string{"a", "b"}, frozen.AsSlice())
+
+	assert.Panics(t, func() {
+		frozen.Add("c")
+	})
+}
+
+func TestSet_Unmarshal(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		expr     string
+	}{
+		{
+			scenario: "set",
+			expr:     `{"a", "b"}`,
+		},
+		{
+			scenario: "frozenset",
+			expr:     `frozenset({"a", "b"})`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o := evalSet(t, tc.expr)
+
+			set, err := python3.UnmarshalAs[*python3.Set[string]](o)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"a", "b"}, set.AsSlice())
+
+			slice, err := python3.UnmarshalAs[[]string](o)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"a", "b"}, slice)
+
+			m, err := python3.UnmarshalAs[map[string]struct{}](o)
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]struct{}{"a": {}, "b": {}}, m)
+
+			v, err := python3.UnmarshalAs[any](o)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []any{"a", "b"}, v)
+		})
+	}
+}
+
+func TestSet_Unmarshal_NotSet(t *testing.T) {
+	withGIL(t)
+
+	o := evalSet(t, `["a"]`)
+
+	_, err := python3.UnmarshalAs[*python3.Set[string]](o)
+
+	require.EqualError(t, err, "python3: cannot unmarshal list into Go value of type *python.Set[string]")
+}
+
+func TestSet_Unmarshal_ItemTypeError(t *testing.T) {
+	withGIL(t)
+
+	o := evalSet(t, `{1}`)
+
+	_, err := python3.UnmarshalAs[map[string]struct{}](o)
+
+	require.EqualError(t, err, "python3: cannot unmarshal int into Go value of type string")
+}
+
+func TestMarshal_Set(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Marshal(map[int]struct{}{1: {}, 2: {}})
+	require.NoError(t, err)
+
+	defer o.DecRef()
+
+	assert.True(t, python3.IsSet(o))
+	assert.False(t, python3.IsFrozenSet(o))
+	assert.Equal(t, `{1, 2}`, o.String())
+}
+
+func TestMarshal_Set_Unhashable(t *testing.T) {
+	withGIL(t)
+
+	o, err := python3.Marshal(map[struct{ A int }]struct{}{{A: 1}: {}})
+
+	assert.Nil(t, o)
+	require.EqualError(t, err, "unhashable type: 'dict'")
+}
@@ -9,6 +9,8 @@ import (
 )
 
 func TestString(t *testing.T) {
+	withGIL(t)
+
 	s := python3.NewString("Hello, World!")
 
 	assert.NotNil(t, s)
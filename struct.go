@@ -0,0 +1,323 @@
+package python
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	cpy3 "go.nhat.io/cpy/v3"
+)
+
+// tagName is the name of the struct tag that controls how a field is marshaled and unmarshaled.
+const tagName = "python"
+
+var fieldCache sync.Map // map[reflect.Type][]field
+
+// field is a struct field that is marshaled to and unmarshaled from a Python object.
+type field struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+}
+
+// cachedTypeFields returns the fields of the struct type t, see typeFields.
+func cachedTypeFields(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field) //nolint: errcheck
+	}
+
+	f, _ := fieldCache.LoadOrStore(t, typeFields(t))
+
+	return f.([]field) //nolint: errcheck
+}
+
+// typeFields returns the fields of the struct type t. The fields of embedded structs are promoted following the same
+// rules as encoding/json: the shallowest field wins, a tagged field wins over an untagged one at the same depth, and
+// fields that are still ambiguous are dropped.
+func typeFields(t reflect.Type) []field {
+	var fields []field
+
+	collectFields(t, nil, map[reflect.Type]bool{}, &fields)
+
+	slices.SortStableFunc(fields, func(a, b field) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+
+		if c := len(a.index) - len(b.index); c != 0 {
+			return c
+		}
+
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+
+			return 1
+		}
+
+		return 0
+	})
+
+	result := make([]field, 0, len(fields))
+
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+
+		if dominant, ok := dominantField(fields[i:j]); ok {
+			result = append(result, dominant)
+		}
+
+		i = j
+	}
+
+	slices.SortFunc(result, func(a, b field) int {
+		return slices.Compare(a.index, b.index)
+	})
+
+	return result
+}
+
+// dominantField returns the field that wins among the fields with the same name, which are sorted by depth and tag.
+func dominantField(fields []field) (field, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return field{}, false
+	}
+
+	return fields[0], true
+}
+
+func collectFields(t reflect.Type, index []int, visited map[reflect.Type]bool, fields *[]field) {
+	if visited[t] {
+		return
+	}
+
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		tag := sf.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		fieldIndex := append(slices.Clone(index), i)
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			collectFields(ft, fieldIndex, visited, fields)
+
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := field{
+			name:      name,
+			index:     fieldIndex,
+			tagged:    name != "",
+			omitEmpty: slices.Contains(strings.Split(opts, ","), "omitempty"),
+		}
+
+		if f.name == "" {
+			f.name = sf.Name
+		}
+
+		*fields = append(*fields, f)
+	}
+}
+
+// fieldByIndex returns the field of v at index. It returns false if the field is behind a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// fieldByIndexAlloc returns the field of v at index, allocating the nil embedded pointers on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+
+	default:
+		return v.IsZero()
+	}
+}
+
+func marshalStruct(v reflect.Value) (*Object, error) {
+	d := NewDictObject()
+
+	for _, f := range cachedTypeFields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+
+		if err := setDictItemString(d, f.name, fv.Interface()); err != nil {
+			d.DecRef()
+
+			return nil, err
+		}
+	}
+
+	return d.AsObject(), nil
+}
+
+// setDictItemString marshals the value and sets it into the dict. PyDict_SetItemString does not steal the reference, so
+// it is released afterward.
+func setDictItemString(d *DictObject, key string, v any) error {
+	value, err := marshalNewRef(v)
+	if err != nil {
+		return err
+	}
+
+	defer value.DecRef()
+
+	if cpy3.PyDict_SetItemString(d.PyObject(), key, value.PyObject()) != 0 {
+		return LastError()
+	}
+
+	return nil
+}
+
+func (d *decoder) unmarshalStruct(o *Object, dest reflect.Value) error {
+	if IsDict(o) {
+		return d.unmarshalStructFromDict(o, dest)
+	}
+
+	if d.attributes {
+		return d.unmarshalStructFromAttributes(o, dest)
+	}
+
+	return &UnmarshalTypeError{Value: TypeName(o), Type: dest.Type()}
+}
+
+func (d *decoder) unmarshalStructFromDict(o *Object, dest reflect.Value) error {
+	t := dest.Type()
+
+	for _, f := range cachedTypeFields(t) {
+		item := NewObject(cpy3.PyDict_GetItemString(o.PyObject(), f.name))
+		if item == nil {
+			continue
+		}
+
+		if err := d.unmarshalField(item, dest, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) unmarshalStructFromAttributes(o *Object, dest reflect.Value) error {
+	t := dest.Type()
+
+	for _, f := range cachedTypeFields(t) {
+		if !o.PyObject().HasAttrString(f.name) {
+			ft := t.FieldByIndex(f.index).Type
+
+			return withFieldPath(&UnmarshalTypeError{Value: TypeName(o) + " without attribute " + f.name, Type: ft}, t, f.name)
+		}
+
+		attr := o.GetAttr(f.name)
+		if attr == nil {
+			return LastError()
+		}
+
+		err := d.unmarshalField(attr, dest, f)
+
+		attr.DecRef()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) unmarshalField(o *Object, dest reflect.Value, f field) error {
+	fv, ok := fieldByIndexAlloc(dest, f.index)
+	if !ok {
+		return nil
+	}
+
+	if err := d.unmarshal(o, fv.Addr().Interface()); err != nil {
+		return withFieldPath(err, dest.Type(), f.name)
+	}
+
+	return nil
+}
+
+// withFieldPath adds the field to the path of an UnmarshalTypeError. The path is built from the innermost struct
+// outward, so the struct is overwritten at each level and ends up as the root struct, like encoding/json does.
+func withFieldPath(err error, t reflect.Type, name string) error {
+	var typeErr *UnmarshalTypeError
+
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	typeErr.Struct = t.Name()
+
+	if typeErr.Field == "" {
+		typeErr.Field = name
+	} else {
+		typeErr.Field = name + "." + typeErr.Field
+	}
+
+	return err
+}
@@ -0,0 +1,412 @@
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cpy3 "go.nhat.io/cpy/v3"
+
+	python3 "go.nhat.io/python/v3"
+)
+
+type base struct {
+	ID int `python:"id"`
+}
+
+type left struct {
+	Name string `python:"name"`
+}
+
+type right struct {
+	Name string `python:"name"`
+}
+
+type Audit struct {
+	CreatedBy string `python:"created_by,omitempty"`
+}
+
+type address struct {
+	Street string `python:"street"`
+	City   string `python:"city,omitempty"`
+}
+
+type person struct {
+	base
+	*Audit
+
+	Name     string `python:"name"`
+	Age      int    `python:"age,omitempty"`
+	Email    string `python:"-"`
+	Nickname string
+	Address  address  `python:"address"`
+	Tags     []string `python:"tags,omitempty"`
+	Manager  *person  `python:"manager,omitempty"`
+
+	secret string
+}
+
+func TestMarshal_Struct(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario string
+		value    any
+		expected string
+	}{
+		{
+			scenario: "empty struct",
+			value:    struct{}{},
+			expected: `{}`,
+		},
+		{
+			scenario: "omit empty",
+			value:    person{Name: "gopher"},
+			expected: `{'id': 0, 'name': 'gopher', 'Nickname': '', 'address': {'street': ''}}`,
+		},
+		{
+			scenario: "all fields",
+			value: &person{
+				base:     base{ID: 42},
+				Audit:    &Audit{CreatedBy: "admin"},
+				Name:     "gopher",
+				Age:      15,
+				Email:    "gopher@example.com",
+				Nickname: "go",
+				Address:  address{Street: "1 Main St", City: "Springfield"},
+				Tags:     []string{"a", "b"},
+				Manager:  &person{Name: "boss"},
+				secret:   "secret",
+			},
+			expected: `{'id': 42, 'created_by': 'admin', 'name': 'gopher', 'age': 15, 'Nickname': 'go', 'address': {'street': '1 Main St', 'city': 'Springfield'}, 'tags': ['a', 'b'], 'manager': {'id': 0, 'name': 'boss', 'Nickname': '', 'address': {'street': ''}}}`,
+		},
+		{
+			scenario: "conflicting embedded fields are dropped",
+			value: struct {
+				left
+				right
+
+				Age int `python:"age"`
+			}{},
+			expected: `{'age': 0}`,
+		},
+		{
+			scenario: "shallower field wins",
+			value: struct {
+				base
+
+				ID string `python:"id"`
+			}{base: base{ID: 1}, ID: "2"},
+			expected: `{'id': '2'}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			actual, err := python3.Marshal(tc.value)
+			require.NoError(t, err)
+
+			defer actual.DecRef()
+
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
+
+func TestMarshal_StructError(t *testing.T) {
+	withGIL(t)
+
+	actual, err := python3.Marshal(struct {
+		Ch chan int `python:"ch"`
+	}{})
+
+	require.EqualError(t, err, "cannot marshal value of chan int to python object")
+	assert.Nil(t, actual)
+}
+
+func TestMarshal_Struct_References(t *testing.T) {
+	withGIL(t)
+
+	item := python3.NewList(0)
+	defer item.DecRef()
+
+	before := refCount(t, item)
+
+	o, err := python3.Marshal(struct {
+		Item  *python3.Object `python:"item"`
+		Value int             `python:"value"`
+	}{Item: item.AsObject(), Value: 1 << 40})
+	require.NoError(t, err)
+
+	assert.Equal(t, before+1, refCount(t, item))
+
+	value := o.GetItem("value")
+	defer value.DecRef()
+
+	// The dict and the GetItem result.
+	assert.Equal(t, 2, refCount(t, value))
+
+	o.DecRef()
+
+	assert.Equal(t, before, refCount(t, item))
+}
+
+func TestUnmarshal_Struct(t *testing.T) {
+	withGIL(t)
+
+	o := python3.MustMarshal(map[string]any{
+		"id":         42,
+		"created_by": "admin",
+		"name":       "gopher",
+		"age":        15,
+		"Email":      "gopher@example.com",
+		"Nickname":   "go",
+		"address":    map[string]any{"street": "1 Main St", "city": "Springfield"},
+		"tags":       []string{"a", "b"},
+		"manager":    map[string]any{"name": "boss"},
+		"unknown":    true,
+	})
+	defer o.DecRef()
+
+	var actual person
+
+	err := python3.Unmarshal(o, &actual)
+	require.NoError(t, err)
+
+	expected := person{
+		base:     base{ID: 42},
+		Audit:    &Audit{CreatedBy: "admin"},
+		Name:     "gopher",
+		Age:      15,
+		Nickname: "go",
+		Address:  address{Street: "1 Main St", City: "Springfield"},
+		Tags:     []string{"a", "b"},
+		Manager:  &person{Name: "boss"},
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnmarshal_StructRoundTrip(t *testing.T) {
+	withGIL(t)
+
+	expected := person{
+		base:    base{ID: 1},
+		Name:    "gopher",
+		Address: address{Street: "1 Main St"},
+	}
+
+	o := python3.MustMarshal(expected)
+	defer o.DecRef()
+
+	actual := python3.MustUnmarshalAs[person](o)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnmarshal_StructError(t *testing.T) {
+	withGIL(t)
+
+	testCases := []struct {
+		scenario      string
+		value         any
+		expectedError string
+	}{
+		{
+			scenario:      "not a dict",
+			value:         []int{1, 2},
+			expectedError: `python3: cannot unmarshal list into Go value of type python_test.person`,
+		},
+		{
+			scenario:      "field",
+			value:         map[string]any{"name": 42},
+			expectedError: `python3: cannot unmarshal int into Go struct field person.name of type string`,
+		},
+		{
+			scenario:      "embedded field",
+			value:         map[string]any{"id": "42"},
+			expectedError: `python3: cannot unmarshal str into Go struct field person.id of type int`,
+		},
+		{
+			scenario:      "nested field",
+			value:         map[string]any{"address": map[string]any{"street": 1}},
+			expectedError: `python3: cannot unmarshal int into Go struct field person.address.street of type string`,
+		},
+		{
+			scenario:      "deeply nested field",
+			value:         map[string]any{"manager": map[string]any{"address": map[string]any{"city": false}}},
+			expectedError: `python3: cannot unmarshal bool into Go struct field person.manager.address.city of type string`,
+		},
+		{
+			scenario:      "slice item",
+			value:         map[string]any{"tags": []any{"a", 1}},
+			expectedError: `python3: cannot unmarshal int into Go struct field person.tags of type string`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			o := python3.MustMarshal(tc.value)
+			defer o.DecRef()
+
+			var actual person
+
+			err := python3.Unmarshal(o, &actual)
+
+			require.EqualError(t, err, tc.expectedError)
+
+			var typeErr *python3.UnmarshalTypeError
+
+			require.ErrorAs(t, err, &typeErr)
+		})
+	}
+}
+
+type point struct {
+	X int `python:"x"`
+	Y int `python:"y"`
+}
+
+type line struct {
+	Name  string `python:"name"`
+	Start point  `python:"start"`
+	End   *point `python:"end"`
+}
+
+func newPyObject(t *testing.T, class *python3.Object, args ...any) *python3.Object {
+	t.Helper()
+
+	pyArgs := make([]*cpy3.PyObject, len(args))
+	for i, arg := range args {
+		pyArgs[i] = python3.MustMarshal(arg).PyObject()
+	}
+
+	o := python3.NewObject(class.PyObject().CallFunctionObjArgs(pyArgs...))
+	require.NotNil(t, o)
+
+	return o
+}
+
+func TestUnmarshal_StructFromAttributes(t *testing.T) {
+	withGIL(t)
+
+	dataclasses := python3.MustImportModule("dataclasses")
+
+	pointClass := dataclasses.CallMethodArgs("make_dataclass", "Point", []string{"x", "y"})
+	defer pointClass.DecRef()
+
+	lineClass := dataclasses.CallMethodArgs("make_dataclass", "Line", []string{"name", "start", "end"})
+	defer lineClass.DecRef()
+
+	start := newPyObject(t, pointClass, 1, 2)
+	defer start.DecRef()
+
+	end := newPyObject(t, pointClass, 3, 4)
+	defer end.DecRef()
+
+	o := newPyObject(t, lineClass, "diagonal", start, end)
+	defer o.DecRef()
+
+	var actual line
+
+	err := python3.Unmarshal(o, &actual, python3.WithAttributes())
+	require.NoError(t, err)
+
+	expected := line{
+		Name:  "diagonal",
+		Start: point{X: 1, Y: 2},
+		End:   &point{X: 3, Y: 4},
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnmarshal_StructFromAttributes_NamedTuple(t *testing.T) {
+	withGIL(t)
+
+	pointClass := python3.MustImportModule("collections").CallMethodArgs("namedtuple", "Point", []string{"x", "y"})
+	defer pointClass.DecRef()
+
+	o := newPyObject(t, pointClass, 5, 6)
+	defer o.DecRef()
+
+	actual, err := python3.UnmarshalAs[point](o, python3.WithAttributes())
+	require.NoError(t, err)
+
+	assert.Equal(t, point{X: 5, Y: 6}, actual)
+}
+
+func TestUnmarshal_StructFromAttributes_PlainObject(t *testing.T) {
+	withGIL(t)
+
+	namespaceClass := python3.MustImportModule("types").GetAttr("SimpleNamespace")
+	defer namespaceClass.DecRef()
+
+	start := newPyObject(t, namespaceClass)
+	defer start.DecRef()
+
+	start.SetAttr("x", 7)
+	start.SetAttr("y", 8)
+
+	o := newPyObject(t, namespaceClass)
+	defer o.DecRef()
+
+	o.SetAttr("name", "plain")
+	o.SetAttr("start", start)
+	o.SetAttr("end", map[string]int{"x": 9, "y": 10})
+
+	actual, err := python3.UnmarshalAs[line](o, python3.WithAttributes())
+	require.NoError(t, err)
+
+	expected := line{
+		Name:  "plain",
+		Start: point{X: 7, Y: 8},
+		End:   &point{X: 9, Y: 10},
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestUnmarshal_StructFromAttributes_Error(t *testing.T) {
+	withGIL(t)
+
+	namespaceClass := python3.MustImportModule("types").GetAttr("SimpleNamespace")
+	defer namespaceClass.DecRef()
+
+	start := newPyObject(t, namespaceClass)
+	defer start.DecRef()
+
+	start.SetAttr("x", 1)
+
+	o := newPyObject(t, namespaceClass)
+	defer o.DecRef()
+
+	o.SetAttr("name", "broken")
+	o.SetAttr("start", start)
+
+	testCases := []struct {
+		scenario      string
+		opts          []python3.UnmarshalOption
+		expectedError string
+	}{
+		{
+			scenario:      "without attributes option",
+			expectedError: `python3: cannot unmarshal SimpleNamespace into Go value of type python_test.line`,
+		},
+		{
+			scenario:      "missing nested attribute",
+			opts:          []python3.UnmarshalOption{python3.WithAttributes()},
+			expectedError: `python3: cannot unmarshal SimpleNamespace without attribute y into Go struct field line.start.y of type int`,
+		},
+	}
+
+	for _, tc := range testCases {
+		run(t, tc.scenario, func(t *testing.T) {
+			var actual line
+
+			err := python3.Unmarshal(o, &actual, tc.opts...)
+
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+import sys
+
+sys.script_result = {"name": __name__, "file": __file__.rsplit("/", 1)[-1]}
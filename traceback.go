@@ -0,0 +1,56 @@
+package python
+
+import cpy3 "go.nhat.io/cpy/v3"
+
+// newTraceback returns the frames of the traceback of the exception, the most recent call last.
+func newTraceback(err *Object) []Frame {
+	tb := NewObject(cpy3.PyException_GetTraceback(err.PyObject()))
+	if tb == nil {
+		return nil
+	}
+
+	var frames []Frame
+
+	for tb != nil && tb.PyObject() != cpy3.Py_None {
+		frames = append(frames, newFrame(tb))
+
+		next := tb.GetAttr("tb_next")
+
+		tb.DecRef()
+
+		tb = next
+	}
+
+	tb.DecRef()
+
+	return frames
+}
+
+// newFrame returns the frame of a traceback object.
+func newFrame(tb *Object) Frame {
+	frame := tb.GetAttr("tb_frame")
+	defer frame.DecRef()
+
+	code := frame.GetAttr("f_code")
+	defer code.DecRef()
+
+	filename := code.GetAttr("co_filename")
+	defer filename.DecRef()
+
+	name := code.GetAttr("co_name")
+	defer name.DecRef()
+
+	line := tb.GetAttr("tb_lineno")
+	defer line.DecRef()
+
+	f := Frame{
+		File:     filename.String(),
+		Function: name.String(),
+	}
+
+	if IsInt(line) {
+		f.Line = AsInt(line)
+	}
+
+	return f
+}
@@ -33,7 +33,7 @@ func (o *TupleObject) Length() int {
 	return (*cpy3.PyObject)(o).Length()
 }
 
-// Set sets the item at index to value.
+// Set sets the item at index to value. A Python object value is stolen, like with PyTuple_SetItem.
 func (o *TupleObject) Set(index int, value any) {
 	defer MustSuccess()
 
@@ -59,7 +59,11 @@ func (o *TupleObject) AsList() *ListObject {
 	l := (*ListObject)(cpy3.PyList_New(o.Length()))
 
 	for i := range o.Length() {
-		l.Set(i, o.Get(i))
+		// Get returns a borrowed reference, and Set steals it.
+		item := o.Get(i)
+		item.PyObject().IncRef()
+
+		l.Set(i, item)
 	}
 
 	return l
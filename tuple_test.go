@@ -10,6 +10,8 @@ import (
 )
 
 func TestTuple_DecRefNil(t *testing.T) {
+	withGIL(t)
+
 	var tuple *python3.AnyTuple
 
 	assert.NotPanics(t, func() {
@@ -18,6 +20,8 @@ func TestTuple_DecRefNil(t *testing.T) {
 }
 
 func TestTuple_IsTuple(t *testing.T) {
+	withGIL(t)
+
 	assert.False(t, python3.IsTuple(python3.NewList(10)))
 	assert.True(t, python3.IsTuple(python3.NewTuple(10)))
 	assert.False(t, python3.IsTuple(python3.NewBool(true)))
@@ -28,6 +32,8 @@ func TestTuple_IsTuple(t *testing.T) {
 }
 
 func TestTuple_Capacity(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTuple(10)
 	defer tuple.DecRef()
 
@@ -42,6 +48,8 @@ func TestTuple_Capacity(t *testing.T) {
 }
 
 func TestTuple_Set(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTuple(1)
 	defer tuple.DecRef()
 
@@ -51,14 +59,16 @@ func TestTuple_Set(t *testing.T) {
 		assert.Equal(t, 1, cpy3.PyLong_AsLong(cpy3.PyTuple_GetItem(tuple.PyObject(), 0)))
 	})
 
-	err := python3.IndexError{Exception: python3.Exception{Message: `tuple assignment index out of range`}}
+	err := python3.IndexError{LookupError: python3.LookupError{Exception: python3.Exception{Message: `tuple assignment index out of range`, Type: "IndexError"}}}
 
-	assert.PanicsWithValue(t, err, func() {
+	assertPanicsWithError(t, err, func() {
 		tuple.Set(1, 2)
 	})
 }
 
 func TestTuple_Get(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTuple(1)
 	defer tuple.DecRef()
 
@@ -68,14 +78,16 @@ func TestTuple_Get(t *testing.T) {
 		assert.Equal(t, int64(1), tuple.Get(0))
 	})
 
-	err := python3.IndexError{Exception: python3.Exception{Message: `tuple index out of range`}}
+	err := python3.IndexError{LookupError: python3.LookupError{Exception: python3.Exception{Message: `tuple index out of range`, Type: "IndexError"}}}
 
-	assert.PanicsWithValue(t, err, func() {
+	assertPanicsWithError(t, err, func() {
 		tuple.Get(1)
 	})
 }
 
 func TestNewTupleForType(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTupleForType[int](3)
 	defer tuple.DecRef()
 
@@ -90,6 +102,8 @@ func TestNewTupleForType(t *testing.T) {
 }
 
 func TestNewTupleFromValues(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTupleFromValues(1, 2, 3)
 	defer tuple.DecRef()
 
@@ -103,6 +117,8 @@ func TestNewTupleFromValues(t *testing.T) {
 }
 
 func TestNewTupleFromAny(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTupleFromAny(1, "hello", 3.14)
 	defer tuple.DecRef()
 
@@ -116,6 +132,8 @@ func TestNewTupleFromAny(t *testing.T) {
 }
 
 func TestTupleOfList(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTupleForType[[]int](1)
 
 	tuple.Set(0, []int{1, 2})
@@ -129,6 +147,8 @@ func TestTupleOfList(t *testing.T) {
 }
 
 func TestTupleOfTuple(t *testing.T) {
+	withGIL(t)
+
 	tuple := python3.NewTupleForType[*python3.Tuple[int]](1)
 
 	tuple.Set(0, python3.NewTupleFromValues(1, 2))
@@ -140,3 +160,26 @@ func TestTupleOfTuple(t *testing.T) {
 
 	assert.True(t, expected.AsObject().Equal(actual.AsObject()))
 }
+
+func TestTupleObject_AsList_References(t *testing.T) {
+	withGIL(t)
+
+	item := python3.NewString("hello")
+	defer item.DecRef()
+
+	tuple := python3.NewTupleObject(1)
+	defer tuple.DecRef()
+
+	item.PyObject().IncRef()
+	tuple.Set(0, item)
+
+	before := refCount(t, item)
+
+	list := tuple.AsList()
+
+	assert.Equal(t, before+1, refCount(t, item))
+
+	list.DecRef()
+
+	assert.Equal(t, before, refCount(t, item))
+}
@@ -1,6 +1,44 @@
 package python
 
+/*
+#cgo pkg-config: python-3.12-embed
+#include "Python.h"
+*/
+import "C"
+
 // TypeName returns the name of the type of the given object.
 func TypeName(o *Object) string {
 	return o.Type().GetAttr("__name__").String()
 }
+
+// isInstanceOf returns true if o is an instance of the class of the module. The module is not imported if it is not
+// already, since o cannot be an instance of its class otherwise.
+func isInstanceOf(o PyObjector, module, class string) bool {
+	name := NewString(module)
+	defer name.DecRef()
+
+	m := fromC(C.PyImport_GetModule(toC(name)))
+	if m == nil {
+		ClearError()
+
+		return false
+	}
+
+	defer m.DecRef()
+
+	cls, err := m.TryGetAttr(class)
+	if err != nil {
+		return false
+	}
+
+	defer cls.DecRef()
+
+	rc := C.PyObject_IsInstance(toC(o), toC(cls))
+	if rc < 0 {
+		ClearError()
+
+		return false
+	}
+
+	return rc == 1
+}
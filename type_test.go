@@ -9,6 +9,8 @@ import (
 )
 
 func TestTypeName(t *testing.T) {
+	withGIL(t)
+
 	testCases := []struct {
 		scenario string
 		value    *python3.Object
@@ -52,7 +54,7 @@ func TestTypeName(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.scenario, func(t *testing.T) {
+		run(t, tc.scenario, func(t *testing.T) {
 			actual := python3.TypeName(tc.value)
 
 			assert.Equal(t, tc.expected, actual)